@@ -0,0 +1,74 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// aggregateParam asks the service for totals over the filtered set.
+const aggregateParam = "aggregate"
+
+// ListAggregates are totals over all files matching a listing's filters, not
+// just the returned page.
+type ListAggregates struct {
+	TotalSize int64 `json:"totalSize"` // bytes
+}
+
+// ListFilesWithTotalSize lists a page like ListFiles and also reports the total
+// size of all files matching the filters in resp.Aggregates.TotalSize, e.g. for
+// "42 files, 1.3 GB". It asks the service to aggregate (aggregate=total_size).
+// When the service returns no aggregate, the SDK sums the sizes itself by
+// reading the whole filtered set: one extra request per page, streamed without
+// keeping the items, so the cost grows with the number of matching files.
+// Prefer narrow filters, or a service with aggregation, for large sets.
+func (c *Client) ListFilesWithTotalSize(queryString string, opts ...CallOption) (*ListFilesResponse, error) {
+	query, err := url.ParseQuery(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query string: %w", err)
+	}
+	query.Set(aggregateParam, "total_size")
+	resp, err := c.ListFiles(query.Encode(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Aggregates != nil {
+		return resp, nil
+	}
+
+	var total int64
+	if p := resp.Pagination; p == nil || (!p.HasNext && !p.HasPrevious) {
+		// The page is the whole set.
+		for _, f := range resp.Data {
+			total += f.FileSize
+		}
+	} else {
+		query.Del(aggregateParam)
+		query.Del("page")
+		if total, err = c.sumFileSizes(query, opts); err != nil {
+			return nil, fmt.Errorf("failed to compute total size: %w", err)
+		}
+	}
+	resp.Aggregates = &ListAggregates{TotalSize: total}
+	return resp, nil
+}
+
+// sumFileSizes adds up FileSize over every page of a listing.
+func (c *Client) sumFileSizes(query url.Values, opts []CallOption) (int64, error) {
+	var total int64
+	page := 0
+	for {
+		if page > 0 {
+			query.Set("page", strconv.Itoa(page))
+		}
+		pagination, err := c.streamListPage(query.Encode(), func(f FileItem) {
+			total += f.FileSize
+		}, opts)
+		if err != nil {
+			return 0, err
+		}
+		if page = nextPageNumber(pagination, page); page == 0 {
+			return total, nil
+		}
+	}
+}
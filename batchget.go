@@ -0,0 +1,64 @@
+package storagesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// BatchGetOptions configures GetFilesByIDs.
+type BatchGetOptions struct {
+	// Concurrency bounds the GetFile requests in flight (default 4).
+	Concurrency int
+
+	// FailFast stops at the first failure: in-flight requests are canceled,
+	// pending ones are not started, and only that error is returned.
+	FailFast bool
+}
+
+// GetFilesByIDs fetches the metadata of many files concurrently and returns them
+// in input order. Without FailFast every ID is attempted; files that could not
+// be fetched are nil and the error joins the per-file errors (each naming the
+// file ID). ctx cancels all in-flight requests. It behaves like
+// errgroup.WithContext with SetLimit, built on forEachConcurrent so the SDK
+// keeps no dependencies outside the standard library.
+func (c *Client) GetFilesByIDs(ctx context.Context, fileIDs []string, opts BatchGetOptions) ([]*FileItem, error) {
+	if len(fileIDs) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	files := make([]*FileItem, len(fileIDs))
+	errs := make([]error, len(fileIDs))
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	forEachConcurrent(len(fileIDs), opts.Concurrency, func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = fmt.Errorf("file %s: %w", fileIDs[i], err)
+			return
+		}
+		resp, err := c.GetFile(fileIDs[i], WithContext(ctx))
+		if err != nil {
+			errs[i] = fmt.Errorf("file %s: %w", fileIDs[i], err)
+			if opts.FailFast {
+				once.Do(func() {
+					firstErr = errs[i]
+					cancel()
+				})
+			}
+			return
+		}
+		files[i] = &resp.Data
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return files, errors.Join(errs...)
+}
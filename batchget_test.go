@@ -0,0 +1,63 @@
+package storagesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetFilesByIDsFailFastCancelsInFlight(t *testing.T) {
+	ids := []string{"slow-1", "bad", "slow-2", "slow-3"}
+	var (
+		arrived  sync.WaitGroup
+		canceled atomic.Int32
+	)
+	arrived.Add(len(ids) - 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		if id == "bad" {
+			// Fail only once every other request is in flight.
+			arrived.Wait()
+			http.Error(w, `{"success":false,"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		arrived.Done()
+		select {
+		case <-r.Context().Done():
+			canceled.Add(1)
+		case <-time.After(5 * time.Second):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"success":true,"data":{"id":%q}}`, id)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, Config{BaseURL: srv.URL})
+
+	start := time.Now()
+	files, err := c.GetFilesByIDs(context.Background(), ids, BatchGetOptions{Concurrency: len(ids), FailFast: true})
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("took %v; in-flight requests were not canceled", elapsed)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want the 404 of the failing file", err)
+	}
+	if files != nil {
+		t.Errorf("files = %v, want nil", files)
+	}
+	// The server notices the canceled requests asynchronously.
+	deadline := time.Now().Add(2 * time.Second)
+	for canceled.Load() < int32(len(ids)-1) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := canceled.Load(); n != int32(len(ids)-1) {
+		t.Errorf("%d of %d in-flight requests were canceled", n, len(ids)-1)
+	}
+}
@@ -0,0 +1,109 @@
+package storagesdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxBulkDeleteBatch is the most file IDs sent in one bulk delete request.
+const maxBulkDeleteBatch = 100
+
+// DeleteResult is the per-file outcome of BulkDelete.
+type DeleteResult struct {
+	FileID string // File that was deleted
+	Err    error  // Non-nil if deleting this file failed
+}
+
+// BulkDeleteRequest is the body of POST /files/bulk-delete.
+type BulkDeleteRequest struct {
+	FileIDs []string `json:"fileIds"`
+}
+
+// BulkDeleteItem is the service's outcome for one file of a bulk delete.
+type BulkDeleteItem struct {
+	FileID  string `json:"fileId"`
+	Success bool   `json:"success"`
+	Status  int    `json:"status,omitempty"` // HTTP status of the failure, if reported
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkDeleteResponse represents the API response from a bulk delete.
+type BulkDeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+	Data    struct {
+		Results []BulkDeleteItem `json:"results"`
+	} `json:"data"`
+}
+
+// BulkDelete deletes many files and returns one result per file ID (in input
+// order). IDs are sent in batches of maxBulkDeleteBatch to POST
+// /files/bulk-delete; if the service has no bulk endpoint, the files are
+// deleted one DeleteFile call each with at most defaultBulkConcurrency in
+// flight. Failures on individual files do not abort the others, so callers
+// can retry only the results with a non-nil Err. A batch request that fails
+// as a whole sets its error on every file of that batch and later batches are
+// still sent. Per-file failures reporting a status are *APIError values.
+func (c *Client) BulkDelete(fileIDs []string, opts ...CallOption) ([]DeleteResult, error) {
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+	for _, id := range fileIDs {
+		if id == "" {
+			return nil, fmt.Errorf("file ID is required")
+		}
+	}
+	results := make([]DeleteResult, len(fileIDs))
+	for start := 0; start < len(fileIDs); start += maxBulkDeleteBatch {
+		end := min(start+maxBulkDeleteBatch, len(fileIDs))
+		err := c.bulkDeleteBatch(fileIDs[start:end], results[start:end], opts)
+		if errors.Is(err, ErrNotSupported) {
+			c.deleteEach(fileIDs[start:], results[start:], opts)
+			return results, nil
+		}
+		if err != nil {
+			for i, id := range fileIDs[start:end] {
+				results[start+i] = DeleteResult{FileID: id, Err: err}
+			}
+		}
+	}
+	return results, nil
+}
+
+// bulkDeleteBatch deletes ids with one bulk request and fills results.
+func (c *Client) bulkDeleteBatch(ids []string, results []DeleteResult, opts []CallOption) error {
+	var resp BulkDeleteResponse
+	err := c.do(http.MethodPost, apiPathPrefix+"/files/bulk-delete", BulkDeleteRequest{FileIDs: ids}, []int{http.StatusOK, http.StatusMultiStatus}, &resp, "failed to delete files", opts...)
+	if err != nil {
+		return asNotSupported(err)
+	}
+	byID := make(map[string]BulkDeleteItem, len(resp.Data.Results))
+	for _, item := range resp.Data.Results {
+		byID[item.FileID] = item
+	}
+	for i, id := range ids {
+		results[i].FileID = id
+		item, ok := byID[id]
+		switch {
+		case !ok:
+			results[i].Err = fmt.Errorf("file %s: not reported by the bulk delete response", id)
+		case item.Success:
+		case item.Status != 0:
+			results[i].Err = &APIError{StatusCode: item.Status, Message: item.Error}
+		case item.Error != "":
+			results[i].Err = fmt.Errorf("file %s: %s", id, item.Error)
+		default:
+			results[i].Err = fmt.Errorf("file %s: not deleted", id)
+		}
+	}
+	return nil
+}
+
+// deleteEach deletes ids one request each with bounded concurrency.
+func (c *Client) deleteEach(ids []string, results []DeleteResult, opts []CallOption) {
+	forEachConcurrent(len(ids), defaultBulkConcurrency, func(i int) {
+		results[i] = DeleteResult{FileID: ids[i], Err: c.DeleteFile(ids[i], opts...)}
+	})
+}
@@ -0,0 +1,53 @@
+package storagesdk
+
+import (
+	"sync"
+	"time"
+)
+
+// CallInfo receives diagnostics about one API call, see WithCallInfo.
+type CallInfo struct {
+	// Duration is the time from sending the call's first HTTP request until
+	// the headers of its last response arrived (reading the body is not
+	// included).
+	Duration time.Duration
+	// Attempts counts the HTTP requests the call sent, including retries and
+	// helper requests such as GetFileLimits for UploadOptions.SkipOversized.
+	Attempts int
+	// StatusCode is the HTTP status of the last response (0 if none arrived).
+	StatusCode int
+
+	mu    sync.Mutex
+	start time.Time
+}
+
+// WithCallInfo fills info with the call's duration, number of HTTP requests
+// and final status code, for investigating a specific call without global
+// instrumentation. info is updated as requests complete and may be read once
+// the call has returned; use a fresh CallInfo for every call.
+func WithCallInfo(info *CallInfo) CallOption {
+	return func(co *callOptions) {
+		co.info = info
+	}
+}
+
+// attemptStarted records that a request of the call is being sent.
+func (i *CallInfo) attemptStarted() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.Attempts == 0 {
+		i.start = time.Now()
+	}
+	i.Attempts++
+}
+
+// attemptDone records the outcome of a request; statusCode is 0 when no
+// response arrived.
+func (i *CallInfo) attemptDone(statusCode int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.Duration = time.Since(i.start)
+	if statusCode != 0 {
+		i.StatusCode = statusCode
+	}
+}
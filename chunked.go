@@ -0,0 +1,274 @@
+package storagesdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// Chunk size bounds for chunked uploads. Larger chunks mean fewer requests on
+// fast links; smaller chunks mean less data to resend when a chunk fails on a
+// flaky link.
+const (
+	DefaultUploadChunkSize = 8 << 20   // 8 MiB
+	MinUploadChunkSize     = 256 << 10 // 256 KiB
+	MaxUploadChunkSize     = 512 << 20 // 512 MiB
+)
+
+// checkChunkSize validates a chunk size against the client-side bounds.
+func checkChunkSize(size int64) error {
+	if size < MinUploadChunkSize || size > MaxUploadChunkSize {
+		return fmt.Errorf("upload chunk size %d is outside [%d, %d]", size, MinUploadChunkSize, MaxUploadChunkSize)
+	}
+	return nil
+}
+
+// ChunkedUpload is the state of a resumable upload session.
+type ChunkedUpload struct {
+	ID           string `json:"uploadId"`
+	FileName     string `json:"fileName"`
+	FileSize     int64  `json:"fileSize"`
+	Offset       int64  `json:"offset"`                 // bytes received by the service so far
+	MinChunkSize int64  `json:"minChunkSize,omitempty"` // server bounds (0 when not announced)
+	MaxChunkSize int64  `json:"maxChunkSize,omitempty"`
+}
+
+// ChunkedUploadResponse represents the API response for an upload session.
+type ChunkedUploadResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Status  int           `json:"status"`
+	Data    ChunkedUpload `json:"data"`
+}
+
+// ChunkedUploadError is returned when a chunked upload fails after its session
+// was created. Pass UploadID to ResumeUpload to continue from Offset.
+type ChunkedUploadError struct {
+	UploadID string
+	Offset   int64 // bytes the service had confirmed when the upload failed
+	Err      error
+}
+
+// Error implements the error interface
+func (e *ChunkedUploadError) Error() string {
+	return fmt.Sprintf("chunked upload %s failed at offset %d: %v", e.UploadID, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ChunkedUploadError) Unwrap() error { return e.Err }
+
+// UploadFileChunked uploads a single file as a sequence of Config.UploadChunkSize
+// chunks through a resumable upload session (POST /files/uploads, then one PUT per
+// chunk with a Content-Range header, then POST /files/uploads/:id/complete). If a
+// chunk fails, the returned *ChunkedUploadError carries the session ID for
+// ResumeUpload. With Config.SessionStore set, the session is checkpointed after
+// every chunk and a later call for the same unchanged file (path, size and
+// modification time) resumes it automatically, also after a restart.
+// opts.Metadata, Folder, ExpiresAt/TTL, IfNotExists and Progress apply; the other
+// options are ignored. WithProgress reports the bytes confirmed after each chunk. Chunked
+// uploads are not available with client-side encryption and return an error
+// wrapping ErrNotSupported if the service has no upload sessions.
+func (c *Client) UploadFileChunked(filePath string, opts UploadOptions, callOpts ...CallOption) (*GetFileResponse, error) {
+	if c.encryption != nil {
+		return nil, fmt.Errorf("failed to upload file: chunked uploads are not available with client-side encryption")
+	}
+	if err := validateFilePaths([]string{filePath}); err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := c.checkUploadPolicy([]string{filePath}); err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	// Chunks and the completion are sent with the caller's options only;
+	// preconditions such as IfNotExists apply to creating the session.
+	chunkOpts := callOpts
+	if opts.Progress != nil {
+		chunkOpts = withOpts(callOpts, WithProgress(opts.Progress))
+	}
+	var key string
+	if c.sessions != nil {
+		key = uploadSessionKey(filePath, info)
+		session, err := c.storedSession(key, callOpts)
+		if err != nil {
+			return nil, err
+		}
+		if session != nil {
+			return c.sendChunks(filePath, session, key, chunkOpts)
+		}
+	}
+	formValues, createOpts, err := c.prepareUpload(opts, nil, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	_, name := splitPath(filePath)
+	body := map[string]interface{}{"fileName": name, "fileSize": info.Size()}
+	for k, values := range formValues {
+		switch {
+		case k == "metadata":
+			body[k] = json.RawMessage(values[0])
+		case len(values) == 1:
+			body[k] = values[0]
+		default:
+			body[k] = values
+		}
+	}
+
+	var session ChunkedUploadResponse
+	err = c.do(http.MethodPost, apiPathPrefix+"/files/uploads", body, []int{http.StatusOK, http.StatusCreated}, &session, "failed to create upload session", createOpts...)
+	if err != nil {
+		if opts.IfNotExists {
+			return nil, asAlreadyExists(err)
+		}
+		return nil, asNotSupported(err)
+	}
+	if key != "" {
+		if err := c.sessions.Save(key, session.Data); err != nil {
+			return nil, &ChunkedUploadError{UploadID: session.Data.ID, Err: fmt.Errorf("save session: %w", err)}
+		}
+	}
+	return c.sendChunks(filePath, &session.Data, key, chunkOpts)
+}
+
+// storedSession returns the session saved under key if the service still
+// knows it. Sessions the service has forgotten (404, 410) are dropped.
+func (c *Client) storedSession(key string, opts []CallOption) (*ChunkedUpload, error) {
+	stored, err := c.sessions.Load(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: load session: %w", err)
+	}
+	if stored == nil {
+		return nil, nil
+	}
+	session, err := c.GetUploadSession(stored.ID, opts...)
+	if apiErr, ok := IsAPIError(err); ok && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone) {
+		if err := c.sessions.Delete(key); err != nil {
+			return nil, fmt.Errorf("failed to upload file: delete session: %w", err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ResumeUpload continues a chunked upload session from the offset the service
+// has confirmed, reading the remaining content from filePath (the same file
+// the session was started with).
+func (c *Client) ResumeUpload(uploadID, filePath string, opts ...CallOption) (*GetFileResponse, error) {
+	if uploadID == "" {
+		return nil, fmt.Errorf("upload ID is required")
+	}
+	session, err := c.GetUploadSession(uploadID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("failed to resume upload: %w", err)
+	} else if session.FileSize > 0 && info.Size() != session.FileSize {
+		return nil, fmt.Errorf("failed to resume upload: %s has %d bytes, session expects %d", filePath, info.Size(), session.FileSize)
+	}
+	return c.sendChunks(filePath, session, "", opts)
+}
+
+// GetUploadSession returns the state of a chunked upload session, including
+// the offset the service has received.
+func (c *Client) GetUploadSession(uploadID string, opts ...CallOption) (*ChunkedUpload, error) {
+	if uploadID == "" {
+		return nil, fmt.Errorf("upload ID is required")
+	}
+	var result ChunkedUploadResponse
+	err := c.do(http.MethodGet, apiPathPrefix+"/files/uploads/"+pathSeg(uploadID), nil, []int{http.StatusOK}, &result, "failed to get upload session", opts...)
+	if err != nil {
+		return nil, asNotSupported(err)
+	}
+	if result.Data.ID == "" {
+		result.Data.ID = uploadID
+	}
+	return &result.Data, nil
+}
+
+// sendChunks uploads the file content from session.Offset onwards and
+// completes the session. With a non-empty key, progress is checkpointed in
+// the client's SessionStore after every chunk and removed on completion.
+func (c *Client) sendChunks(filePath string, session *ChunkedUpload, key string, opts []CallOption) (*GetFileResponse, error) {
+	chunkSize := c.uploadChunkSize
+	if (session.MinChunkSize > 0 && chunkSize < session.MinChunkSize) || (session.MaxChunkSize > 0 && chunkSize > session.MaxChunkSize) {
+		return nil, fmt.Errorf("failed to upload file: chunk size %d is outside the server's bounds [%d, %d]", chunkSize, session.MinChunkSize, session.MaxChunkSize)
+	}
+	co := newCallOptions(opts)
+	f, err := openFileContext(co.context(), filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	size := info.Size()
+
+	path := apiPathPrefix + "/files/uploads/" + pathSeg(session.ID)
+	offset := session.Offset
+	for offset < size {
+		n := min(chunkSize, size-offset)
+		if err := c.putChunk(path, f, offset, n, size, co); err != nil {
+			return nil, &ChunkedUploadError{UploadID: session.ID, Offset: offset, Err: err}
+		}
+		offset += n
+		if key != "" {
+			checkpoint := *session
+			checkpoint.Offset = offset
+			if err := c.sessions.Save(key, checkpoint); err != nil {
+				return nil, &ChunkedUploadError{UploadID: session.ID, Offset: offset, Err: fmt.Errorf("save session: %w", err)}
+			}
+		}
+		if co.progress != nil {
+			co.progress(offset, size)
+		}
+	}
+
+	var result GetFileResponse
+	err = c.do(http.MethodPost, path+"/complete", nil, []int{http.StatusOK, http.StatusCreated}, &result, "failed to complete upload", opts...)
+	if err != nil {
+		return nil, &ChunkedUploadError{UploadID: session.ID, Offset: offset, Err: err}
+	}
+	if key != "" {
+		// The file is stored; a stale session entry is dropped on the next attempt.
+		c.sessions.Delete(key)
+	}
+	return &result, nil
+}
+
+// putChunk sends bytes [offset, offset+n) of f as one chunk.
+func (c *Client) putChunk(path string, f *os.File, offset, n, size int64, co *callOptions) error {
+	section := io.NewSectionReader(f, offset, n)
+	req, err := http.NewRequestWithContext(co.context(), http.MethodPut, c.baseURL+path, &ctxReader{ctx: co.context(), r: section})
+	if err != nil {
+		return err
+	}
+	req.ContentLength = n
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(io.NewSectionReader(f, offset, n)), nil }
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", "bytes "+strconv.FormatInt(offset, 10)+"-"+strconv.FormatInt(offset+n-1, 10)+"/"+strconv.FormatInt(size, 10))
+
+	resp, err := c.send(req, co)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if !statusIn(resp.StatusCode, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}) {
+		if err := redirectError(resp); err != nil {
+			return err
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return c.apiError(resp.StatusCode, body)
+	}
+	return nil
+}
@@ -2,33 +2,202 @@ package storagesdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	apiPathPrefix  = "/api/v1"
-	defaultTimeout = 10 * time.Second
+	apiPathPrefix              = "/api/v1"
+	defaultTimeout             = 10 * time.Second
+	defaultIdleConnTimeout     = 30 * time.Second
+	defaultMaxIdleConnsPerHost = 16
 )
 
 // Config holds configuration for the storage service client
 type Config struct {
 	BaseURL string        // Storage service base URL (e.g., "http://localhost:3003")
 	Timeout time.Duration // Request timeout (default: 10 seconds)
+
+	// IdleConnTimeout closes pooled connections idle for longer than this
+	// (default: 30 seconds), below common server/proxy keep-alive timeouts so
+	// the first request after a quiet period does not hit a reset connection.
+	IdleConnTimeout time.Duration
+
+	// MaxIdleConnsPerHost is the number of idle connections kept to the
+	// service (default: 16), the upper bound for Warmup to be effective.
+	MaxIdleConnsPerHost int
+
+	// HTTP2Cleartext forces HTTP/2 without TLS (h2c, prior knowledge) for
+	// plain-HTTP deployments behind an h2c-capable proxy, multiplexing
+	// concurrent requests over one connection. The server must speak h2c;
+	// HTTP/1.1 is then disabled, also for https URLs. HTTP/2 over TLS is
+	// negotiated automatically without this option.
+	HTTP2Cleartext bool
+
+	// UserAgent is sent as the User-Agent header of every request so SDK
+	// traffic can be identified in the service's logs (default:
+	// DefaultUserAgent, "storage-service-sdk-go/<Version>"). A User-Agent set
+	// per call with WithHeader takes precedence.
+	UserAgent string
+
+	// HTTPClient, when set, sends all requests instead of a client built by
+	// NewClient, e.g. for a custom transport, proxy or instrumentation. A
+	// copy is used: Timeout replaces its timeout when set, RequireHTTPS and
+	// DisableRedirects replace its redirect policy, and IdleConnTimeout,
+	// MaxIdleConnsPerHost and HTTP2Cleartext are not applied (configure its
+	// transport instead).
+	HTTPClient *http.Client
+
+	// RequireHTTPS rejects BaseURLs that are not https:// and refuses
+	// redirects to plain-HTTP URLs, guarding against accidental plaintext
+	// configuration in production.
+	RequireHTTPS bool
+
+	// VerifyAPIVersion makes NewClient call CheckAPIVersion and fail on an
+	// unreachable server or an incompatible API version.
+	VerifyAPIVersion bool
+
+	// ErrorFields lists JSON fields to read the error message from in error
+	// responses, tried in order (default: "error", then "message"). Nested
+	// fields use dot paths, e.g. "detail" or "errors.0.message".
+	ErrorFields []string
+
+	// ErrorParser, when set, builds the APIError for error responses. Returning
+	// nil falls back to ErrorFields and the default parsing.
+	ErrorParser func(statusCode int, body []byte) *APIError
+
+	// Encryption, when set, encrypts file content client-side before upload and
+	// decrypts downloads, so the service only stores ciphertext. Uploaded files
+	// get metadata "encrypted": true. The service sees ciphertext sizes, hashes
+	// and MIME types, and range downloads are unavailable. Downloads are only
+	// decrypted when the content is encrypted (see EncryptionDetector), so
+	// files stored without encryption are still returned as they are.
+	Encryption EncryptionProvider
+
+	// PathPrefix namespaces all uploads from this client (e.g. "billing-app").
+	// It is sent as the upload "folder" (joined with UploadOptions.Folder) and
+	// ListFiles results are scoped to it.
+	PathPrefix string
+
+	// TempDir is where large request bodies (multipart uploads of local files
+	// over 32 MiB) are spooled instead of being held in memory (default:
+	// os.TempDir()). Use it when the default temp directory is small or on
+	// the wrong volume. NewClient fails if it does not exist or is not
+	// writable.
+	TempDir string
+
+	// Signer, when set, signs every request (see NewHMACSigner for the
+	// built-in HMAC-SHA256 signer). The signature covers the body's SHA-256,
+	// so streamed request bodies are buffered before sending.
+	Signer Signer
+
+	// AuthToken, when set, is sent as "Authorization: Bearer <token>" with
+	// every request, including downloads and ServeFileContent. TokenSource
+	// is called for every request instead (also for every retry), so tokens
+	// can be refreshed when they expire; an error fails the request before
+	// it is sent. Set at most one of them. An Authorization header set per
+	// call with WithHeader takes precedence.
+	AuthToken   string
+	TokenSource func() (string, error)
+
+	// UploadChunkSize is the chunk size of chunked (resumable) uploads, see
+	// UploadFileChunked (default: 8 MiB, allowed: 256 KiB to 512 MiB). Larger
+	// chunks reduce per-request overhead on fast links; smaller chunks reduce
+	// the data resent after a failure on flaky links.
+	UploadChunkSize int64
+
+	// PauseOnRateLimit makes requests wait until X-RateLimit-Reset when the
+	// last response reported X-RateLimit-Remaining: 0, instead of running
+	// into 429 responses. The wait honours the call's context.
+	PauseOnRateLimit bool
+
+	// MaxRetries is how often the SDK retries a failed request after the
+	// first attempt; 0, the default, disables retries. Idempotent requests
+	// (GET, HEAD, PUT, DELETE and OPTIONS) are retried on network errors and
+	// on 408, 425, 429, 500, 502, 503 and 504, honouring Retry-After up to
+	// 30s; uploads only with RetryUploads. RetryBaseDelay is the wait before
+	// the first retry (default 500ms), doubling for every further retry up to
+	// 30s. Retries stop when the wait would pass the call context's deadline.
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+
+	// RetryUploads retries uploads of local files that fail transiently (see
+	// MaxRetries): network errors, 408, 425, 429, 500, 502, 503 and 504, or
+	// any error whose JSON body has "retryable": true. Validation and size
+	// errors (400, 413, 415, 422) and bodies with "retryable": false are never
+	// retried. Files are reopened for every attempt; uploads of readers are
+	// not retried. A retried upload whose earlier attempt was stored despite
+	// the error can create a duplicate (or fail with ErrAlreadyExists under
+	// IfNotExists).
+	RetryUploads bool
+
+	// DisableRedirects stops the client from following HTTP redirects. API
+	// calls answered with a redirect then fail with a *RedirectError naming
+	// the Location, which surfaces misconfigured proxies instead of silently
+	// talking to another endpoint. Downloads return the 3xx response as an
+	// *APIError.
+	DisableRedirects bool
+
+	// SnakeCaseJSON decodes responses from deployments that name JSON fields
+	// in snake_case ("original_name") instead of camelCase, by rewriting
+	// response keys before decoding. User metadata keys are left unchanged,
+	// and request bodies are still sent in camelCase. Without it, a response
+	// that looks snake_case fails with ErrFieldNameMismatch rather than
+	// decoding into empty results.
+	SnakeCaseJSON bool
+
+	// SessionStore, when set, persists chunked upload sessions so that
+	// UploadFileChunked resumes interrupted uploads, also across process
+	// restarts. NewFileSessionStore provides a file-based store.
+	SessionStore SessionStore
+
+	// UploadPolicy, when set, is called for every local file before it is
+	// uploaded (UploadFile, UploadFileWithOptions, UploadArchive,
+	// UploadFileChunked) to enforce app-specific rules such as image
+	// dimensions. A non-nil error rejects the file and fails the upload before
+	// anything is sent; all rejections are reported together.
+	UploadPolicy func(path string, info os.FileInfo) error
 }
 
 // Client is the storage service HTTP client (plain HTTP).
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	pathPrefix  string
+	errorFields []string
+	errorParser func(statusCode int, body []byte) *APIError
+	encryption  EncryptionProvider
+	tempDir     string
+	signer      Signer
+	token       func() (string, error)
+	userAgent   string
+	uploadStats throughputStats
+	limits      limitsCache
+	rateLimit   rateLimitState
+	life        lifecycle
+	config      Config // resolved configuration, see Config()
+	policy      func(path string, info os.FileInfo) error
+	sessions    SessionStore
+
+	uploadChunkSize  int64
+	pauseOnRateLimit bool
+	maxRetries       int
+	retryBaseDelay   time.Duration
+	retryUploads     bool
+	snakeCaseJSON    bool
 }
 
 // APIError represents an error returned by the storage service API
@@ -46,6 +215,17 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("storage service returned status %d: %s", e.StatusCode, e.Body)
 }
 
+// IsRetryable reports whether the status code indicates a transient failure
+// (408, 425, 429, 500, 502, 503 or 504) that may succeed when retried.
+func (e *APIError) IsRetryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
 // IsAPIError checks if an error is an APIError and returns it
 func IsAPIError(err error) (*APIError, bool) {
 	var apiErr *APIError
@@ -82,6 +262,58 @@ func parseErrorResponse(statusCode int, body []byte) *APIError {
 	}
 }
 
+// apiError builds the APIError for an error response, honoring Config.ErrorParser and
+// Config.ErrorFields before the default parsing (which falls back to the raw body).
+func (c *Client) apiError(statusCode int, body []byte) *APIError {
+	if c.errorParser != nil {
+		if apiErr := c.errorParser(statusCode, body); apiErr != nil {
+			return apiErr
+		}
+	}
+	if len(c.errorFields) > 0 {
+		if msg := lookupErrorField(body, c.errorFields); msg != "" {
+			return &APIError{StatusCode: statusCode, Message: msg, Body: string(body)}
+		}
+	}
+	return parseErrorResponse(statusCode, body)
+}
+
+// lookupErrorField returns the first non-empty string or number found at one of the dot
+// paths in a JSON body.
+func lookupErrorField(body []byte, fields []string) string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return ""
+	}
+	for _, field := range fields {
+		v := doc
+		for _, key := range strings.Split(field, ".") {
+			switch node := v.(type) {
+			case map[string]interface{}:
+				v = node[key]
+			case []interface{}:
+				i, err := strconv.Atoi(key)
+				if err != nil || i < 0 || i >= len(node) {
+					v = nil
+				} else {
+					v = node[i]
+				}
+			default:
+				v = nil
+			}
+		}
+		switch val := v.(type) {
+		case string:
+			if val != "" {
+				return val
+			}
+		case float64:
+			return strconv.FormatFloat(val, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
 func statusIn(code int, codes []int) bool {
 	for _, c := range codes {
 		if code == c {
@@ -92,20 +324,24 @@ func statusIn(code int, codes []int) bool {
 }
 
 // do performs a JSON request, checks status, and optionally decodes JSON into result.
-func (c *Client) do(method, path string, body interface{}, successStatuses []int, result interface{}, wrapErr string) error {
-	resp, err := c.doRequest(method, path, body)
+func (c *Client) do(method, path string, body interface{}, successStatuses []int, result interface{}, wrapErr string, opts ...CallOption) error {
+	resp, err := c.doRequest(method, path, body, opts...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", wrapErr, err)
 	}
 	defer resp.Body.Close()
 
+	successStatuses = newCallOptions(opts).successStatuses(successStatuses)
 	if !statusIn(resp.StatusCode, successStatuses) {
+		if err := redirectError(resp); err != nil {
+			return err
+		}
 		respBody, _ := io.ReadAll(resp.Body)
-		return parseErrorResponse(resp.StatusCode, respBody)
+		return c.apiError(resp.StatusCode, respBody)
 	}
 
 	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		if err := c.decodeResponse(resp, result); err != nil {
 			return fmt.Errorf("%s: %w", wrapErr, err)
 		}
 	}
@@ -113,87 +349,344 @@ func (c *Client) do(method, path string, body interface{}, successStatuses []int
 }
 
 // doRequest performs an HTTP request with optional JSON body.
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) doRequest(method, path string, body interface{}, opts ...CallOption) (*http.Response, error) {
+	co := newCallOptions(opts)
 	fullURL := c.baseURL + path
 	var bodyReader io.Reader
+	var pipe *io.PipeReader
 	if body != nil {
-		raw, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("marshal body: %w", err)
+		if co.streamBody {
+			pipe = streamJSON(body)
+			bodyReader = pipe
+		} else {
+			raw, err := json.Marshal(body)
+			if err != nil {
+				return nil, fmt.Errorf("marshal body: %w", err)
+			}
+			bodyReader = bytes.NewReader(raw)
 		}
-		bodyReader = bytes.NewReader(raw)
 	}
-	req, err := http.NewRequest(method, fullURL, bodyReader)
+	req, err := http.NewRequestWithContext(co.context(), method, fullURL, bodyReader)
 	if err != nil {
+		if pipe != nil {
+			pipe.Close()
+		}
 		return nil, err
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	return c.httpClient.Do(req)
+	return c.send(req, co)
 }
 
-// doMultipart performs a multipart/form-data POST and optionally decodes JSON response.
-func (c *Client) doMultipart(path string, formFiles map[string][]string, formValues map[string]string, successStatuses []int, result interface{}, wrapErr string) error {
-	body := &bytes.Buffer{}
-	w := multipart.NewWriter(body)
+// streamJSON encodes body into a pipe from a separate goroutine, avoiding the
+// copy json.Marshal makes of the encoder's buffer. Encoding errors surface to
+// the HTTP transport as a read error; closing the reader stops the encoder.
+func streamJSON(body interface{}) *io.PipeReader {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(json.NewEncoder(pw).Encode(body))
+	}()
+	return pr
+}
 
-	for field, paths := range formFiles {
-		for _, filePath := range paths {
-			f, err := os.Open(filePath)
-			if err != nil {
-				return fmt.Errorf("%s: open file %s: %w", wrapErr, filePath, err)
-			}
-			_, name := splitPath(filePath)
-			part, err := w.CreateFormFile(field, name)
-			if err != nil {
-				f.Close()
-				return fmt.Errorf("%s: create form file: %w", wrapErr, err)
-			}
-			if _, err := io.Copy(part, f); err != nil {
-				f.Close()
-				return fmt.Errorf("%s: copy file: %w", wrapErr, err)
-			}
-			f.Close()
+// sendOnce dispatches a prepared request and applies per-call options to the
+// response. See send for the retrying variant every request goes through.
+func (c *Client) sendOnce(req *http.Request, co *callOptions) (*http.Response, error) {
+	body := req.Body
+	closeBody := func() {
+		if body != nil {
+			body.Close()
+		}
+	}
+	req, done, err := c.track(req)
+	if err != nil {
+		closeBody()
+		return nil, err
+	}
+	co.applyRequest(req)
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.token != nil && req.Header.Get("Authorization") == "" {
+		if err := c.authorize(req); err != nil {
+			closeBody()
+			done()
+			return nil, err
 		}
 	}
+	if c.pauseOnRateLimit {
+		if err := c.waitForRateLimit(req.Context()); err != nil {
+			closeBody()
+			done()
+			return nil, err
+		}
+	}
+	if c.signer != nil {
+		if err := c.signRequest(req); err != nil {
+			closeBody()
+			done()
+			return nil, err
+		}
+	}
+	if co.info != nil {
+		co.info.attemptStarted()
+	}
+	resp, err := c.httpClient.Do(req)
+	if co.info != nil {
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		co.info.attemptDone(status)
+	}
+	if err != nil {
+		done()
+		return nil, err
+	}
+	resp.Body = &trackedBody{ReadCloser: resp.Body, done: done}
+	c.recordRateLimit(resp)
+	co.captureResponse(resp)
+	if c.signer != nil {
+		if err := c.checkClockSkew(resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
 
-	for k, v := range formValues {
-		if err := w.WriteField(k, v); err != nil {
-			return fmt.Errorf("%s: write field: %w", wrapErr, err)
+// authorize sets the bearer token from AuthToken or TokenSource on req.
+func (c *Client) authorize(req *http.Request) error {
+	token, err := c.token()
+	if err != nil {
+		return fmt.Errorf("get auth token: %w", err)
+	}
+	if token == "" {
+		return fmt.Errorf("get auth token: token source returned an empty token")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// formFile is a single file part of a multipart request. Content is read from
+// reader when set, otherwise from the local file at path.
+type formFile struct {
+	field  string
+	name   string
+	path   string
+	reader io.Reader
+}
+
+// replayable reports whether the parts can be sent again (none reads from a
+// one-shot reader).
+func replayable(files []formFile) bool {
+	for _, ff := range files {
+		if ff.reader != nil {
+			return false
 		}
 	}
+	return true
+}
 
-	if err := w.Close(); err != nil {
-		return fmt.Errorf("%s: close multipart: %w", wrapErr, err)
+// pathFormFiles returns one part per local file path, all under field.
+func pathFormFiles(field string, paths []string) []formFile {
+	files := make([]formFile, len(paths))
+	for i, p := range paths {
+		_, name := splitPath(p)
+		files[i] = formFile{field: field, name: name, path: p}
 	}
+	return files
+}
 
-	fullURL := c.baseURL + path
-	req, err := http.NewRequest(http.MethodPost, fullURL, body)
+// writeFormFile copies ff into a new part of w, encrypting it when the call requests it.
+// Opening and reading honour the call's context (see openFileContext).
+func (c *Client) writeFormFile(w *multipart.Writer, ff formFile, co *callOptions) error {
+	ctx := co.context()
+	src, name := ff.reader, ff.name
+	if src == nil {
+		f, err := openFileContext(ctx, ff.path)
+		if err != nil {
+			return fmt.Errorf("open file %s: %w", ff.path, err)
+		}
+		defer f.Close()
+		src = f
+		if name == "" {
+			_, name = splitPath(ff.path)
+		}
+	}
+	part, err := w.CreateFormFile(ff.field, name)
 	if err != nil {
+		return fmt.Errorf("create form file: %w", err)
+	}
+	if ctx.Done() != nil {
+		src = &ctxReader{ctx: ctx, r: src}
+	}
+	if co.encrypt && c.encryption != nil {
+		src = c.encryption.Encrypt(src)
+	}
+	if _, err := io.Copy(part, src); err != nil {
+		return fmt.Errorf("copy file: %w", err)
+	}
+	return nil
+}
+
+// multipartContentType returns w's Content-Type with any extra parameters added.
+func multipartContentType(w *multipart.Writer, params map[string]string) string {
+	if len(params) == 0 {
+		return w.FormDataContentType()
+	}
+	all := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		all[strings.ToLower(k)] = v
+	}
+	all["boundary"] = w.Boundary()
+	return mime.FormatMediaType("multipart/form-data", all)
+}
+
+// writeMultipart writes the multipart/form-data body of files and formValues
+// to dst and returns its Content-Type.
+func (c *Client) writeMultipart(dst io.Writer, files []formFile, formValues url.Values, co *callOptions) (string, error) {
+	w := multipart.NewWriter(dst)
+	if err := c.writeParts(w, files, formValues, co); err != nil {
+		return "", err
+	}
+	return multipartContentType(w, co.mpParams), nil
+}
+
+// writeParts writes the parts of files and formValues to w and closes it.
+func (c *Client) writeParts(w *multipart.Writer, files []formFile, formValues url.Values, co *callOptions) error {
+	for _, ff := range files {
+		if err := c.writeFormFile(w, ff, co); err != nil {
+			return err
+		}
+	}
+	for k, values := range formValues {
+		for _, v := range values {
+			if err := w.WriteField(k, v); err != nil {
+				return fmt.Errorf("write field: %w", err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("close multipart: %w", err)
+	}
+	return nil
+}
+
+// doMultipart performs a multipart/form-data POST and optionally decodes JSON response.
+// Uploads from files on disk are spooled so the request has a Content-Length
+// and can be retried; uploads that read an io.Reader are streamed instead
+// (see newStreamingMultipartRequest).
+func (c *Client) doMultipart(path string, files []formFile, formValues url.Values, successStatuses []int, result interface{}, wrapErr string, opts ...CallOption) error {
+	co := newCallOptions(opts)
+	successStatuses = co.successStatuses(successStatuses)
+	var paths []string
+	for _, ff := range files {
+		if ff.reader == nil {
+			paths = append(paths, ff.path)
+		}
+	}
+	if err := validateFilePaths(paths); err != nil {
 		return fmt.Errorf("%s: %w", wrapErr, err)
 	}
-	req.Header.Set("Content-Type", w.FormDataContentType())
 
-	resp, err := c.httpClient.Do(req)
+	fullURL := c.baseURL + path
+	var req *http.Request
+	var bodySize func() int64
+	if replayable(files) {
+		body := &spool{dir: c.tempDir}
+		defer body.Close()
+		contentType, err := c.writeMultipart(body, files, formValues, co)
+		if err != nil {
+			return fmt.Errorf("%s: %w", wrapErr, err)
+		}
+		var reqBody io.Reader = body.reader()
+		if co.progress != nil {
+			reqBody = &progressReader{r: reqBody, total: body.size, fn: co.progress}
+		}
+		req, err = http.NewRequestWithContext(co.context(), http.MethodPost, fullURL, reqBody)
+		if err != nil {
+			return fmt.Errorf("%s: %w", wrapErr, err)
+		}
+		req.Header.Set("Content-Type", contentType)
+		req.ContentLength = body.size
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(body.reader()), nil }
+		bodySize = func() int64 { return body.size }
+	} else {
+		streamReq, sent, closeBody, err := c.newStreamingMultipartRequest(fullURL, files, formValues, co)
+		if err != nil {
+			return fmt.Errorf("%s: %w", wrapErr, err)
+		}
+		defer closeBody()
+		req, bodySize = streamReq, sent
+	}
+
+	start := time.Now()
+	resp, err := c.send(req, co)
 	if err != nil {
 		return fmt.Errorf("%s: %w", wrapErr, err)
 	}
 	defer resp.Body.Close()
+	if statusIn(resp.StatusCode, successStatuses) {
+		c.uploadStats.record(bodySize(), time.Since(start))
+	}
 
 	if !statusIn(resp.StatusCode, successStatuses) {
+		if err := redirectError(resp); err != nil {
+			return err
+		}
 		respBody, _ := io.ReadAll(resp.Body)
-		return parseErrorResponse(resp.StatusCode, respBody)
+		return c.apiError(resp.StatusCode, respBody)
 	}
 
 	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		if err := c.decodeResponse(resp, result); err != nil {
 			return fmt.Errorf("%s: %w", wrapErr, err)
 		}
 	}
 	return nil
 }
 
+// newStreamingMultipartRequest builds a POST whose multipart body is written
+// by a goroutine through an io.Pipe while the request is sent, so reader
+// uploads are neither buffered in memory nor spooled to Config.TempDir. The
+// request uses chunked transfer encoding and has no GetBody, so it is never
+// retried. sent reports the bytes read by the transport; closeBody stops the
+// writer if the request ends before the body was consumed.
+func (c *Client) newStreamingMultipartRequest(fullURL string, files []formFile, formValues url.Values, co *callOptions) (req *http.Request, sent func() int64, closeBody func(), err error) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	contentType := multipartContentType(w, co.mpParams)
+	counter := &sentCounter{r: pr}
+	var reqBody io.Reader = counter
+	if co.progress != nil {
+		reqBody = &progressReader{r: reqBody, total: -1, fn: co.progress}
+	}
+	req, err = http.NewRequestWithContext(co.context(), http.MethodPost, fullURL, reqBody)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = -1
+	go func() {
+		pw.CloseWithError(c.writeParts(w, files, formValues, co))
+	}()
+	return req, counter.n.Load, func() { pr.Close() }, nil
+}
+
+// sentCounter counts bytes read from r. The count is atomic because the
+// transport may still be reading the body when the response arrives.
+type sentCounter struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (s *sentCounter) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.n.Add(int64(n))
+	return n, err
+}
+
 func splitPath(p string) (dir, file string) {
 	i := len(p) - 1
 	for i >= 0 && p[i] != '/' && p[i] != '\\' {
@@ -211,15 +704,158 @@ func NewClient(config Config) (*Client, error) {
 	}
 
 	baseURL := strings.TrimRight(config.BaseURL, "/")
+	if config.RequireHTTPS {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		if u.Scheme != "https" {
+			return nil, fmt.Errorf("base URL must use https when RequireHTTPS is set, got %q", baseURL)
+		}
+	}
+	uploadChunkSize := config.UploadChunkSize
+	if uploadChunkSize == 0 {
+		uploadChunkSize = DefaultUploadChunkSize
+	}
+	if err := checkChunkSize(uploadChunkSize); err != nil {
+		return nil, err
+	}
+	if config.AuthToken != "" && config.TokenSource != nil {
+		return nil, fmt.Errorf("set either AuthToken or TokenSource, not both")
+	}
+	if config.TempDir != "" {
+		if err := checkTempDir(config.TempDir); err != nil {
+			return nil, fmt.Errorf("invalid temp dir: %w", err)
+		}
+	}
 	timeout := config.Timeout
 	if timeout == 0 {
 		timeout = defaultTimeout
 	}
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	retryBaseDelay := config.RetryBaseDelay
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = defaultRetryBaseDelay
+	}
 
-	return &Client{
-		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: timeout},
-	}, nil
+	var httpClient *http.Client
+	var idleConnTimeout time.Duration
+	var maxIdleConnsPerHost int
+	if config.HTTPClient != nil {
+		// Copy, so the redirect policy below does not change the caller's client.
+		hc := *config.HTTPClient
+		if config.Timeout != 0 {
+			hc.Timeout = timeout
+		}
+		timeout = hc.Timeout
+		httpClient = &hc
+	} else {
+		idleConnTimeout = config.IdleConnTimeout
+		if idleConnTimeout == 0 {
+			idleConnTimeout = defaultIdleConnTimeout
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.IdleConnTimeout = idleConnTimeout
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+		if transport.MaxIdleConnsPerHost == 0 {
+			transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+		}
+		if config.HTTP2Cleartext {
+			protocols := new(http.Protocols)
+			protocols.SetHTTP2(true)
+			protocols.SetUnencryptedHTTP2(true)
+			transport.Protocols = protocols
+		}
+		maxIdleConnsPerHost = transport.MaxIdleConnsPerHost
+		httpClient = &http.Client{Timeout: timeout, Transport: transport}
+	}
+	if config.RequireHTTPS {
+		httpClient.CheckRedirect = rejectInsecureRedirect
+	}
+	if config.DisableRedirects {
+		httpClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	}
+
+	c := &Client{
+		baseURL:     baseURL,
+		httpClient:  httpClient,
+		pathPrefix:  strings.Trim(config.PathPrefix, "/"),
+		errorFields: config.ErrorFields,
+		errorParser: config.ErrorParser,
+		encryption:  config.Encryption,
+		tempDir:     config.TempDir,
+		signer:      config.Signer,
+		token:       config.TokenSource,
+		userAgent:   userAgent,
+		sessions:    config.SessionStore,
+		policy:      config.UploadPolicy,
+
+		uploadChunkSize:  uploadChunkSize,
+		pauseOnRateLimit: config.PauseOnRateLimit,
+		maxRetries:       max(config.MaxRetries, 0),
+		retryBaseDelay:   retryBaseDelay,
+		retryUploads:     config.RetryUploads,
+		snakeCaseJSON:    config.SnakeCaseJSON,
+	}
+	if token := config.AuthToken; token != "" {
+		c.token = func() (string, error) { return token, nil }
+	}
+	c.life.ctx, c.life.cancel = context.WithCancel(context.Background())
+
+	resolved := config
+	resolved.BaseURL = baseURL
+	resolved.Timeout = timeout
+	resolved.UserAgent = userAgent
+	resolved.IdleConnTimeout = idleConnTimeout
+	resolved.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	resolved.PathPrefix = c.pathPrefix
+	resolved.UploadChunkSize = uploadChunkSize
+	resolved.MaxRetries = c.maxRetries
+	resolved.RetryBaseDelay = retryBaseDelay
+	resolved.ErrorFields = append([]string(nil), config.ErrorFields...)
+	if resolved.AuthToken != "" {
+		resolved.AuthToken = "[REDACTED]"
+	}
+	c.config = resolved
+	if config.VerifyAPIVersion {
+		if err := c.CheckAPIVersion(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// rejectInsecureRedirect is an http.Client CheckRedirect policy refusing redirects to
+// non-https URLs, otherwise matching the default policy.
+func rejectInsecureRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("refusing redirect to insecure URL %s", req.URL.Redacted())
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// Config returns the client's effective configuration, with defaults applied
+// (e.g. Timeout is 10s when it was left zero) and BaseURL and PathPrefix
+// normalized. Secrets are never included: AuthToken reads "[REDACTED]", and
+// the built-in Signer and Encryption implementations print only a redacted
+// description.
+func (c *Client) Config() Config {
+	config := c.config
+	config.ErrorFields = append([]string(nil), c.config.ErrorFields...)
+	return config
+}
+
+// Close releases idle pooled connections. The client remains usable; see
+// Shutdown to also cancel in-flight requests.
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
 }
 
 // FileItem represents a file in API responses (list, get, upload)
@@ -251,6 +887,14 @@ type Pagination struct {
 	PreviousPage *int  `json:"previousPage,omitempty"`
 }
 
+// UploadDedup is the deduplication outcome of one uploaded file. When the
+// service deduplicates an upload, Deduplicated is true and ExistingFileID is
+// the ID of the stored file that was reused instead of storing a new copy.
+type UploadDedup struct {
+	Deduplicated   bool   `json:"deduplicated,omitempty"`
+	ExistingFileID string `json:"existingFileId,omitempty"`
+}
+
 // UploadFileResponse represents the response from uploading files
 type UploadFileResponse struct {
 	Success bool   `json:"success"`
@@ -262,25 +906,141 @@ type UploadFileResponse struct {
 		Successful    int                      `json:"successful"`
 		Failed        int                      `json:"failed"`
 		FailedUploads []map[string]interface{} `json:"failedUploads,omitempty"`
+		Warnings      []string                 `json:"warnings,omitempty"`
+
+		// Dedup holds the deduplication outcome of each entry of
+		// UploadedFiles, at the same index.
+		Dedup []UploadDedup `json:"-"`
 	} `json:"data"`
+
+	// Skipped lists files left out client-side before the request (see
+	// UploadOptions.SkipOversized); it is not part of the API response.
+	Skipped []SkippedFile `json:"-"`
+
+	// Renamed maps local paths to the name they were uploaded under when
+	// UploadOptions.RenameDuplicates changed it; it is not part of the API response.
+	Renamed map[string]string `json:"-"`
+
+	httpStatus   int        // status code of the HTTP response
+	fileWarnings [][]string // per-file warnings, parallel to Data.UploadedFiles
 }
 
-// UploadFile uploads one or more files. filePaths are local paths; metadataJSON is optional JSON object string applied to all files.
-func (c *Client) UploadFile(filePaths []string, metadataJSON string) (*UploadFileResponse, error) {
-	if len(filePaths) == 0 {
-		return nil, fmt.Errorf("at least one file path is required")
+// UnmarshalJSON decodes an upload response, collecting the per-file fields
+// that are not part of FileItem into Data.Dedup and Warnings. When the service
+// signals a dedup hit without a separate existingFileId, the returned item
+// itself is the existing file.
+func (r *UploadFileResponse) UnmarshalJSON(data []byte) error {
+	type plain UploadFileResponse
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
 	}
-	formFiles := map[string][]string{"files": filePaths}
-	formValues := make(map[string]string)
-	if metadataJSON != "" {
-		formValues["metadata"] = metadataJSON
+	var extra struct {
+		Data struct {
+			UploadedFiles []struct {
+				UploadDedup
+				Warnings []string `json:"warnings"`
+			} `json:"uploadedFiles"`
+		} `json:"data"`
 	}
-	var result UploadFileResponse
-	err := c.doMultipart(apiPathPrefix+"/files/", formFiles, formValues, []int{http.StatusCreated, http.StatusPartialContent}, &result, "failed to upload files")
-	if err != nil {
-		return nil, err
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
 	}
-	return &result, nil
+	r.Data.Dedup = make([]UploadDedup, len(r.Data.UploadedFiles))
+	r.fileWarnings = make([][]string, len(r.Data.UploadedFiles))
+	for i, f := range extra.Data.UploadedFiles {
+		if i >= len(r.Data.UploadedFiles) {
+			break
+		}
+		if f.Deduplicated && f.ExistingFileID == "" {
+			f.ExistingFileID = r.Data.UploadedFiles[i].ID
+		}
+		r.Data.Dedup[i] = f.UploadDedup
+		r.fileWarnings[i] = f.Warnings
+	}
+	return nil
+}
+
+// IsPartial reports whether only some of the files were stored: the service
+// answered 206 Partial Content or counted failed files. FailedUploads is then
+// non-empty; files the service did not describe are listed by "fileName".
+func (r *UploadFileResponse) IsPartial() bool {
+	return r.httpStatus == http.StatusPartialContent || r.Status == http.StatusPartialContent ||
+		r.Data.Failed > 0 || len(r.Data.FailedUploads) > 0
+}
+
+// fillFailedUploads makes sure a partial upload of files lists its failures,
+// naming the files that are missing from UploadedFiles when the service did
+// not report them.
+func (r *UploadFileResponse) fillFailedUploads(files []formFile) {
+	if !r.IsPartial() || len(r.Data.FailedUploads) > 0 {
+		return
+	}
+	stored := make(map[string]int, len(r.Data.UploadedFiles))
+	for _, f := range r.Data.UploadedFiles {
+		stored[f.OriginalName]++
+	}
+	for _, ff := range files {
+		name := ff.name
+		if name == "" {
+			_, name = splitPath(ff.path)
+		}
+		if stored[name] > 0 {
+			stored[name]--
+			continue
+		}
+		r.Data.FailedUploads = append(r.Data.FailedUploads, map[string]interface{}{"fileName": name, "error": "not stored"})
+	}
+	if len(r.Data.FailedUploads) == 0 {
+		reason := r.Message
+		if reason == "" {
+			reason = "partial upload"
+		}
+		r.Data.FailedUploads = append(r.Data.FailedUploads, map[string]interface{}{"error": reason})
+	}
+}
+
+// Warnings returns the soft issues the service reported for the upload (for
+// example metadata that was partially ignored): request-level warnings first,
+// then per-file ones prefixed with the file's original name. Unlike
+// FailedUploads, the affected files were stored. It is nil when the service
+// reported none.
+func (r *UploadFileResponse) Warnings() []string {
+	warnings := append([]string(nil), r.Data.Warnings...)
+	for i, fileWarnings := range r.fileWarnings {
+		for _, w := range fileWarnings {
+			warnings = append(warnings, r.Data.UploadedFiles[i].OriginalName+": "+w)
+		}
+	}
+	return warnings
+}
+
+// StoredNames maps the original name of each uploaded file to the name the
+// service stored it under. Files sharing an original name were stored as
+// separate entries when their stored names (and IDs) differ.
+func (r *UploadFileResponse) StoredNames() map[string][]string {
+	names := make(map[string][]string, len(r.Data.UploadedFiles))
+	for _, f := range r.Data.UploadedFiles {
+		names[f.OriginalName] = append(names[f.OriginalName], f.StoredName)
+	}
+	return names
+}
+
+// DeduplicatedFiles returns the uploaded files the service deduplicated against
+// existing content (no new storage was consumed for them); Data.Dedup has the
+// ID of the existing file each one reused.
+func (r *UploadFileResponse) DeduplicatedFiles() []FileItem {
+	var files []FileItem
+	for i, d := range r.Data.Dedup {
+		if d.Deduplicated {
+			files = append(files, r.Data.UploadedFiles[i])
+		}
+	}
+	return files
+}
+
+// UploadFile uploads one or more files. filePaths are local paths; metadataJSON is optional JSON object string applied to all files.
+func (c *Client) UploadFile(filePaths []string, metadataJSON string, opts ...CallOption) (*UploadFileResponse, error) {
+	return c.UploadFileWithOptions(filePaths, UploadOptions{Metadata: metadataJSON}, opts...)
 }
 
 // ValidateFileResponse represents the response from validating files
@@ -307,16 +1067,38 @@ type ValidationResultItem struct {
 	Description      string `json:"description"`
 	MaxSize          int64  `json:"maxSize"`
 	MaxSizeFormatted string `json:"maxSizeFormatted"`
+	StoredName       string `json:"storedName,omitempty"` // name the service would store the file under, if it reports one
+}
+
+// storedNameIDPlaceholder stands for the identifier the service generates for
+// a stored file in names predicted by PredictedStoredName.
+const storedNameIDPlaceholder = "{id}"
+
+// PredictedStoredName returns the name the file would be stored under. When
+// the service reported StoredName during validation, that name is returned
+// and exact is true. Otherwise the name is a client-side best effort: the
+// service's generated identifier, shown as "{id}", followed by the normalized
+// extension (e.g. "{id}.jpg"), and exact is false.
+func (v ValidationResultItem) PredictedStoredName() (name string, exact bool) {
+	if v.StoredName != "" {
+		return v.StoredName, true
+	}
+	ext := v.Extension
+	if ext == "" {
+		ext = filepath.Ext(v.OriginalName)
+	}
+	return storedNameIDPlaceholder + normalizeExtension(ext), false
 }
 
 // ValidateFile validates files without uploading. filePaths are local paths.
-func (c *Client) ValidateFile(filePaths []string) (*ValidateFileResponse, error) {
+// See ValidationResultItem.PredictedStoredName for the names files would be
+// stored under.
+func (c *Client) ValidateFile(filePaths []string, opts ...CallOption) (*ValidateFileResponse, error) {
 	if len(filePaths) == 0 {
 		return nil, fmt.Errorf("at least one file path is required")
 	}
-	formFiles := map[string][]string{"files": filePaths}
 	var result ValidateFileResponse
-	err := c.doMultipart(apiPathPrefix+"/files/validate", formFiles, nil, []int{http.StatusOK}, &result, "failed to validate files")
+	err := c.doMultipart(apiPathPrefix+"/files/validate", pathFormFiles("files", filePaths), nil, []int{http.StatusOK}, &result, "failed to validate files", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -330,19 +1112,32 @@ type ListFilesResponse struct {
 	Status     int         `json:"status"`
 	Data       []FileItem  `json:"data"`
 	Pagination *Pagination `json:"pagination,omitempty"`
+
+	// Aggregates holds totals over the whole filtered set when they were
+	// requested (see ListFilesWithTotalSize).
+	Aggregates *ListAggregates `json:"aggregates,omitempty"`
+
+	// ETag is the entity tag of the listing from the response header, if the
+	// service sent one; pass it to ListFilesIfChanged on the next poll.
+	ETag string `json:"-"`
 }
 
 // ListFiles lists files with optional query string (page, per_page, filters, e.g. status_eq=active&file_type_eq=jpg).
-func (c *Client) ListFiles(queryString string) (*ListFilesResponse, error) {
+// Use BuildQuery to build the query string with properly escaped values.
+// When Config.PathPrefix is set, results are scoped to that namespace.
+func (c *Client) ListFiles(queryString string, opts ...CallOption) (*ListFilesResponse, error) {
 	path := apiPathPrefix + "/files"
+	queryString = c.scopeQuery(queryString)
 	if queryString != "" {
 		path += "?" + queryString
 	}
 	var result ListFilesResponse
-	err := c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list files")
+	var header http.Header
+	err := c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list files", withOpts(opts, WithResponseHeader(&header))...)
 	if err != nil {
 		return nil, err
 	}
+	result.ETag = header.Get("ETag")
 	return &result, nil
 }
 
@@ -355,13 +1150,13 @@ type GetFileResponse struct {
 }
 
 // GetFile retrieves file metadata by ID.
-func (c *Client) GetFile(fileID string) (*GetFileResponse, error) {
+func (c *Client) GetFile(fileID string, opts ...CallOption) (*GetFileResponse, error) {
 	if fileID == "" {
 		return nil, fmt.Errorf("file ID is required")
 	}
 	path := apiPathPrefix + "/files/" + pathSeg(fileID)
 	var result GetFileResponse
-	err := c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get file")
+	err := c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get file", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -370,19 +1165,37 @@ func (c *Client) GetFile(fileID string) (*GetFileResponse, error) {
 
 // DownloadFile performs GET /files/:id?download=true and returns the HTTP response. Caller must close resp.Body.
 // Use resp.Header.Get("Content-Disposition") for suggested filename if needed.
-func (c *Client) DownloadFile(fileID string) (*http.Response, error) {
+func (c *Client) DownloadFile(fileID string, opts ...CallOption) (*http.Response, error) {
 	if fileID == "" {
 		return nil, fmt.Errorf("file ID is required")
 	}
+	var item *FileItem
+	if newCallOptions(opts).verifyHash {
+		info, err := c.GetFile(fileID, metadataOpts(opts)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download file: %w", err)
+		}
+		if info.Data.HashAlgorithm() == "" {
+			return nil, fmt.Errorf("failed to download file: file %s has no hash of a known algorithm", fileID)
+		}
+		item = &info.Data
+	}
 	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "?download=true"
-	resp, err := c.doRequest(http.MethodGet, path, nil)
+	resp, err := c.doRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download file: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, parseErrorResponse(resp.StatusCode, body)
+		return nil, c.apiError(resp.StatusCode, body)
+	}
+	if item != nil {
+		// The service hashes the stored bytes, so the check runs before decryption.
+		resp.Body = newHashingBody(resp.Body, item)
+	}
+	if err := c.decryptResponse(resp, fileID, item, opts); err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
 	}
 	return resp, nil
 }
@@ -400,9 +1213,9 @@ type GetFileLimitsResponse struct {
 }
 
 // GetFileLimits returns file size limits and upload limits.
-func (c *Client) GetFileLimits() (*GetFileLimitsResponse, error) {
+func (c *Client) GetFileLimits(opts ...CallOption) (*GetFileLimitsResponse, error) {
 	var result GetFileLimitsResponse
-	err := c.do(http.MethodGet, apiPathPrefix+"/files/limits", nil, []int{http.StatusOK}, &result, "failed to get file limits")
+	err := c.do(http.MethodGet, apiPathPrefix+"/files/limits", nil, []int{http.StatusOK}, &result, "failed to get file limits", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -412,18 +1225,18 @@ func (c *Client) GetFileLimits() (*GetFileLimitsResponse, error) {
 // UpdateFileRequest represents the request body for updating a file
 type UpdateFileRequest struct {
 	FileName *string                 `json:"fileName,omitempty"`
-	Status   *string                 `json:"status,omitempty"` // active, inactive, archived, deleted
+	Status   *string                 `json:"status,omitempty"` // a FileStatus; see SetStatus
 	Metadata *map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // UpdateFile updates file metadata by ID.
-func (c *Client) UpdateFile(fileID string, req UpdateFileRequest) (*GetFileResponse, error) {
+func (c *Client) UpdateFile(fileID string, req UpdateFileRequest, opts ...CallOption) (*GetFileResponse, error) {
 	if fileID == "" {
 		return nil, fmt.Errorf("file ID is required")
 	}
 	path := apiPathPrefix + "/files/" + pathSeg(fileID)
 	var result GetFileResponse
-	err := c.do(http.MethodPut, path, req, []int{http.StatusOK}, &result, "failed to update file")
+	err := c.do(http.MethodPut, path, req, []int{http.StatusOK}, &result, "failed to update file", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -431,10 +1244,10 @@ func (c *Client) UpdateFile(fileID string, req UpdateFileRequest) (*GetFileRespo
 }
 
 // DeleteFile deletes a file and its record by ID.
-func (c *Client) DeleteFile(fileID string) error {
+func (c *Client) DeleteFile(fileID string, opts ...CallOption) error {
 	if fileID == "" {
 		return fmt.Errorf("file ID is required")
 	}
 	path := apiPathPrefix + "/files/" + pathSeg(fileID)
-	return c.do(http.MethodDelete, path, nil, []int{http.StatusOK, http.StatusNoContent}, nil, "failed to delete file")
+	return c.do(http.MethodDelete, path, nil, []int{http.StatusOK, http.StatusNoContent}, nil, "failed to delete file", opts...)
 }
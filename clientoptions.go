@@ -0,0 +1,80 @@
+package storagesdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a client created with NewClientWithOptions. Each option
+// sets fields of the Config passed to NewClient, so new settings can be added
+// as options without changing existing callers.
+type Option func(*Config)
+
+// NewClientWithOptions creates a client for baseURL configured by opts:
+//
+//	client, err := storagesdk.NewClientWithOptions("https://storage.internal",
+//		storagesdk.WithTimeout(30*time.Second),
+//		storagesdk.WithAuthToken(token),
+//		storagesdk.WithRetries(3, time.Second),
+//	)
+//
+// It is equivalent to NewClient with the resulting Config; WithConfig sets
+// any field without a dedicated option.
+func NewClientWithOptions(baseURL string, opts ...Option) (*Client, error) {
+	config := Config{BaseURL: baseURL}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&config)
+		}
+	}
+	return NewClient(config)
+}
+
+// WithConfig applies fn to the Config, for settings without a dedicated option.
+func WithConfig(fn func(*Config)) Option {
+	return fn
+}
+
+// WithTimeout sets Config.Timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = timeout
+	}
+}
+
+// WithHTTPClient sets Config.HTTPClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithAuthToken sets Config.AuthToken.
+func WithAuthToken(token string) Option {
+	return func(c *Config) {
+		c.AuthToken = token
+	}
+}
+
+// WithTokenSource sets Config.TokenSource.
+func WithTokenSource(source func() (string, error)) Option {
+	return func(c *Config) {
+		c.TokenSource = source
+	}
+}
+
+// WithRetries sets Config.MaxRetries and Config.RetryBaseDelay (0 keeps the
+// default delay).
+func WithRetries(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Config) {
+		c.MaxRetries = maxRetries
+		c.RetryBaseDelay = baseDelay
+	}
+}
+
+// WithUserAgent sets Config.UserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) {
+		c.UserAgent = userAgent
+	}
+}
@@ -0,0 +1,81 @@
+package storagesdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Clock differences at which a rejected signed request is attributed to clock
+// skew: above clockSkewThreshold always, above minClockSkew when the error
+// message also points at the timestamp. Date headers have one-second
+// resolution, so smaller differences are noise.
+const (
+	clockSkewThreshold = 30 * time.Second
+	minClockSkew       = 2 * time.Second
+)
+
+// clockSkewHints are substrings of error messages that services use for
+// signatures rejected because of their timestamp.
+var clockSkewHints = []string{"skew", "clock", "timestamp", "expired", "request time"}
+
+// ErrClockSkew is matched (with errors.Is) by *ClockSkewError.
+var ErrClockSkew = errors.New("clock skew")
+
+// ClockSkewError is returned when the service rejects a signed request (see
+// Config.Signer) because the local clock differs from the server's, which
+// makes signatures look expired or not yet valid. Synchronize the clock
+// (e.g. with NTP). It wraps the service's *APIError.
+type ClockSkewError struct {
+	ServerTime time.Time     // from the response's Date header
+	LocalTime  time.Time     // when the response was received
+	Skew       time.Duration // LocalTime minus ServerTime; positive when the local clock is ahead
+	Err        *APIError
+}
+
+// Error implements the error interface
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("signed request rejected, local clock is %s off the server's (server time %s); check clock synchronization: %v",
+		e.Skew.Round(time.Second), e.ServerTime.UTC().Format(time.RFC3339), e.Err)
+}
+
+// Unwrap returns ErrClockSkew and the underlying *APIError.
+func (e *ClockSkewError) Unwrap() []error { return []error{ErrClockSkew, e.Err} }
+
+// checkClockSkew inspects a 401 or 403 answer to a signed request. If the
+// server's Date differs enough from the local clock (see clockSkewThreshold),
+// it consumes the response and returns a *ClockSkewError; otherwise it leaves
+// the response readable and returns nil.
+func (c *Client) checkClockSkew(resp *http.Response) error {
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	local := time.Now()
+	server, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body = readCloser{Reader: bytes.NewReader(body), Closer: resp.Body}
+	apiErr := c.apiError(resp.StatusCode, body)
+	skew := local.Sub(server)
+	if skew.Abs() <= clockSkewThreshold && (skew.Abs() <= minClockSkew || !hasClockSkewHint(apiErr.Message)) {
+		return nil
+	}
+	resp.Body.Close()
+	return &ClockSkewError{ServerTime: server, LocalTime: local, Skew: skew, Err: apiErr}
+}
+
+func hasClockSkewHint(message string) bool {
+	message = strings.ToLower(message)
+	for _, hint := range clockSkewHints {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+	return false
+}
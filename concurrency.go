@@ -0,0 +1,33 @@
+package storagesdk
+
+import "sync"
+
+// defaultBulkConcurrency bounds in-flight requests for client-side bulk operations.
+const defaultBulkConcurrency = 4
+
+// forEachConcurrent calls fn for every index in [0, n) with at most concurrency calls in
+// flight (defaultBulkConcurrency when concurrency <= 0) and returns when all have finished.
+func forEachConcurrent(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
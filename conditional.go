@@ -0,0 +1,44 @@
+package storagesdk
+
+import "net/http"
+
+// ListFilesIfChanged lists files like ListFiles but sends If-None-Match with
+// etag (usually ListFilesResponse.ETag from a previous call). When the listing
+// is unchanged it returns ErrNotModified instead of the page, which makes
+// frequent polling cheap. An empty etag performs an unconditional listing.
+// If the service ignores If-None-Match but returns the same ETag, the result is
+// still reported as ErrNotModified (weak comparison).
+func (c *Client) ListFilesIfChanged(queryString, etag string, opts ...CallOption) (*ListFilesResponse, error) {
+	if etag == "" {
+		return c.ListFiles(queryString, opts...)
+	}
+	result, err := c.ListFiles(queryString, withOpts(opts, WithHeader("If-None-Match", etag))...)
+	if err != nil {
+		return nil, asNotModified(err)
+	}
+	if WeakETagMatch(result.ETag, etag) {
+		return nil, ErrNotModified
+	}
+	return result, nil
+}
+
+// ServeFileContentCached is ServeFileContent with If-None-Match set to etag
+// (usually the ETag header of a previous response). When the content is
+// unchanged it returns ErrNotModified instead of a response, so a caching
+// proxy can keep serving its copy. An empty etag fetches unconditionally.
+// As with ListFilesIfChanged, a 200 response carrying the same ETag is also
+// reported as ErrNotModified (weak comparison). Caller must close resp.Body.
+func (c *Client) ServeFileContentCached(fileID, etag string, opts ...CallOption) (*http.Response, error) {
+	if etag == "" {
+		return c.ServeFileContent(fileID, opts...)
+	}
+	resp, err := c.ServeFileContent(fileID, withOpts(opts, WithHeader("If-None-Match", etag))...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified || WeakETagMatch(resp.Header.Get("ETag"), etag) {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+	return resp, nil
+}
@@ -0,0 +1,46 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ConvertFileRequest represents the request body for converting a file
+type ConvertFileRequest struct {
+	Format string `json:"format"` // Target format, e.g. "pdf" or "webp"
+}
+
+// ConversionJob is the outcome of ConvertFile.
+type ConversionJob struct {
+	FileID  string   // File that holds (or will hold) the converted content
+	Status  string   // Status of that file when the request returned
+	Pending bool     // The service converts asynchronously (202 Accepted)
+	File    FileItem // Metadata returned by the service (may be partial while Pending)
+}
+
+// ConvertFile asks the service to convert a file to targetFormat (e.g. docx to pdf). The
+// converted content may be a new file or the same file with new content; job.FileID names it
+// (fileID itself when an asynchronous response does not say). When the service converts
+// asynchronously (202 Accepted), job.Pending is true and the file is not yet active; wait for
+// it with WaitUntilStatus(job.FileID, string(StatusActive), ...). Services without
+// conversion support yield an error wrapping ErrNotSupported.
+func (c *Client) ConvertFile(fileID, targetFormat string, opts ...CallOption) (*ConversionJob, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if targetFormat == "" {
+		return nil, fmt.Errorf("target format is required")
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "/convert"
+	var result GetFileResponse
+	var status int
+	err := c.do(http.MethodPost, path, ConvertFileRequest{Format: targetFormat}, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}, &result, "failed to convert file", withOpts(opts, withStatusCode(&status))...)
+	if err != nil {
+		return nil, asNotSupported(err)
+	}
+	job := &ConversionJob{FileID: result.Data.ID, Status: result.Data.Status, Pending: status == http.StatusAccepted, File: result.Data}
+	if job.FileID == "" {
+		job.FileID = fileID
+	}
+	return job, nil
+}
@@ -0,0 +1,69 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AccessCookieRequest is the request body for GetAccessCookie.
+type AccessCookieRequest struct {
+	PathPrefix string `json:"pathPrefix"`
+	ExpiresIn  int64  `json:"expiresIn"` // seconds
+}
+
+// AccessCookieResponse represents the API response for an access cookie. Services
+// may return the cookie in the body, in a Set-Cookie header, or both.
+type AccessCookieResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+	Data    struct {
+		Name      string    `json:"name"`
+		Value     string    `json:"value"`
+		Path      string    `json:"path,omitempty"`
+		Domain    string    `json:"domain,omitempty"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	} `json:"data"`
+}
+
+// GetAccessCookie obtains a signed cookie granting read access to all content
+// under pathPrefix for expiry, so a browser session can load many files with
+// one grant (galleries, video segments). Forward the cookie to the browser,
+// e.g. with http.SetCookie. It returns an error wrapping ErrNotSupported if the
+// service does not issue access cookies.
+func (c *Client) GetAccessCookie(pathPrefix string, expiry time.Duration, opts ...CallOption) (*http.Cookie, error) {
+	if expiry <= 0 {
+		return nil, fmt.Errorf("expiry must be positive")
+	}
+	req := AccessCookieRequest{PathPrefix: c.uploadFolder(pathPrefix), ExpiresIn: int64(expiry.Round(time.Second) / time.Second)}
+	if req.ExpiresIn == 0 {
+		req.ExpiresIn = 1
+	}
+	var result AccessCookieResponse
+	var header http.Header
+	err := c.do(http.MethodPost, apiPathPrefix+"/files/access-cookie", req, []int{http.StatusOK, http.StatusCreated}, &result, "failed to get access cookie", withOpts(opts, WithResponseHeader(&header))...)
+	if err != nil {
+		return nil, asNotSupported(err)
+	}
+	if cookies := (&http.Response{Header: header}).Cookies(); len(cookies) > 0 {
+		return cookies[0], nil
+	}
+	if result.Data.Name == "" {
+		return nil, fmt.Errorf("failed to get access cookie: no cookie in response")
+	}
+	cookie := &http.Cookie{
+		Name:     result.Data.Name,
+		Value:    result.Data.Value,
+		Path:     result.Data.Path,
+		Domain:   result.Data.Domain,
+		Expires:  result.Data.ExpiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if cookie.Expires.IsZero() {
+		cookie.Expires = time.Now().Add(expiry)
+	}
+	return cookie, nil
+}
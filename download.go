@@ -0,0 +1,242 @@
+package storagesdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DownloadFileAs downloads a file under a caller-chosen filename. The name is sent as the
+// "filename" query parameter for servers that honor it, and the returned response's
+// Content-Disposition is set to an attachment with that name either way, so proxies that
+// copy headers present the desired name. Caller must close resp.Body.
+func (c *Client) DownloadFileAs(fileID, filename string, opts ...CallOption) (*http.Response, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "?" + url.Values{"download": {"true"}, "filename": {filename}}.Encode()
+	resp, err := c.doRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, c.apiError(resp.StatusCode, body)
+	}
+	resp.Header.Set("Content-Disposition", ContentDisposition(filename))
+	if err := c.decryptResponse(resp, fileID, nil, opts); err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return resp, nil
+}
+
+// ContentDisposition returns an attachment Content-Disposition header value for filename,
+// using RFC 5987 encoding for non-ASCII names.
+func ContentDisposition(filename string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+}
+
+// ServeFileContent performs GET /files/:id/content and returns the HTTP response for inline
+// serving (e.g. images in a browser). Caller must close resp.Body. Conditional headers such as
+// If-None-Match may be sent with WithHeader; the server then answers 304 Not Modified with an
+// empty body, which is returned as a response rather than an error.
+func (c *Client) ServeFileContent(fileID string, opts ...CallOption) (*http.Response, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "/content"
+	resp, err := c.doRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serve file content: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotModified {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, c.apiError(resp.StatusCode, body)
+	}
+	if err := c.decryptResponse(resp, fileID, nil, opts); err != nil {
+		return nil, fmt.Errorf("failed to serve file content: %w", err)
+	}
+	return resp, nil
+}
+
+// GetFileBytes downloads a file's content into memory. It returns an error
+// wrapping ErrIncompleteDownload if the connection ends before the full
+// Content-Length was received.
+func (c *Client) GetFileBytes(fileID string, opts ...CallOption) ([]byte, error) {
+	resp, err := c.DownloadFile(fileID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	if resp.ContentLength > 0 {
+		buf.Grow(int(resp.ContentLength))
+	}
+	if _, err := copyBody(&buf, resp, newCallOptions(opts).progress); err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DownloadToFile downloads a file's content to destPath. Content is written to
+// a temporary file in the same directory and renamed into place only after the
+// full body was received, so an interrupted download never leaves a truncated
+// file at destPath.
+func (c *Client) DownloadToFile(fileID, destPath string, opts ...CallOption) error {
+	if destPath == "" {
+		return fmt.Errorf("destination path is required")
+	}
+	resp, err := c.DownloadFile(fileID, opts...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := createPartFile(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer tmp.discard()
+	if _, err := copyBody(tmp, resp, newCallOptions(opts).progress); err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	if err := tmp.commit(); err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	return nil
+}
+
+// DownloadRange downloads bytes [start, end] of a file (inclusive; end < 0
+// means to the end of the file) with a Range header and returns the 206
+// Partial Content response. A server that ignores Range is accepted for
+// ranges starting at 0, where the 200 response carries the whole file;
+// otherwise the error wraps ErrNotSupported. A range outside the file fails
+// with an *APIError for 416 Range Not Satisfiable. Caller must close
+// resp.Body.
+func (c *Client) DownloadRange(fileID string, start, end int64, opts ...CallOption) (*http.Response, error) {
+	if start < 0 || (end >= 0 && end < start) {
+		return nil, fmt.Errorf("invalid range %d-%d", start, end)
+	}
+	return c.getRange(fileID, start, end, opts...)
+}
+
+// AcceptsRanges reports whether resp shows the server supports byte-range
+// requests: a 206 Partial Content status or an Accept-Ranges: bytes header.
+func AcceptsRanges(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	if resp.StatusCode == http.StatusPartialContent {
+		return true
+	}
+	for _, unit := range strings.Split(resp.Header.Get("Accept-Ranges"), ",") {
+		if strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+			return true
+		}
+	}
+	return false
+}
+
+// getRange performs a ranged download of bytes [start, end] (end < 0 means to the end of the
+// file) and returns the 206 response. A server that ignores the Range header is accepted only
+// for ranges starting at 0, where the full body is equivalent; otherwise the error wraps
+// ErrNotSupported. Caller must close resp.Body.
+func (c *Client) getRange(fileID string, start, end int64, opts ...CallOption) (*http.Response, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if c.encryption != nil {
+		return nil, fmt.Errorf("range downloads are not available for client-side encrypted content")
+	}
+	rangeHeader := fmt.Sprintf("bytes=%d-", start)
+	if end >= 0 {
+		rangeHeader += strconv.FormatInt(end, 10)
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "?download=true"
+	resp, err := c.doRequest(http.MethodGet, path, nil, withOpts(opts, WithHeader("Range", rangeHeader))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download range: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		return resp, nil
+	case resp.StatusCode == http.StatusOK:
+		if start == 0 {
+			return resp, nil
+		}
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download range: %w: server ignored Range header", ErrNotSupported)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, c.apiError(resp.StatusCode, body)
+	}
+}
+
+// DownloadTo streams a file's content into w and returns the number of bytes written. It
+// returns an error wrapping ErrIncompleteDownload if the body ends before the advertised
+// Content-Length; bytes already written to w are then incomplete.
+func (c *Client) DownloadTo(fileID string, w io.Writer, opts ...CallOption) (int64, error) {
+	if w == nil {
+		return 0, fmt.Errorf("writer is required")
+	}
+	resp, err := c.DownloadFile(fileID, opts...)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	n, err := copyBody(w, resp, newCallOptions(opts).progress)
+	if err != nil {
+		return n, fmt.Errorf("failed to download file: %w", err)
+	}
+	return n, nil
+}
+
+// copyBody copies a response body to dst, reporting progress when set, and verifies that the
+// advertised Content-Length (when known) was received in full.
+func copyBody(dst io.Writer, resp *http.Response, progress ProgressFunc) (int64, error) {
+	var src io.Reader = resp.Body
+	if progress != nil {
+		src = &progressReader{r: resp.Body, total: resp.ContentLength, fn: progress}
+	}
+	n, err := io.Copy(dst, src)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) && resp.ContentLength >= 0 {
+			return n, incompleteDownloadError(n, resp.ContentLength)
+		}
+		return n, err
+	}
+	if resp.ContentLength >= 0 && n != resp.ContentLength {
+		return n, incompleteDownloadError(n, resp.ContentLength)
+	}
+	return n, nil
+}
+
+// progressReader counts bytes read and reports them to a ProgressFunc.
+type progressReader struct {
+	r     io.Reader
+	done  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.done += int64(n)
+		p.fn(p.done, p.total)
+	}
+	return n, err
+}
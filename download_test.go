@@ -0,0 +1,73 @@
+package storagesdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// truncatingServer announces a body of size bytes, sends only the first sent
+// bytes and then drops the connection.
+func truncatingServer(t *testing.T, size, sent int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(size))
+		w.WriteHeader(http.StatusOK)
+		w.Write(make([]byte, sent))
+		w.(http.Flusher).Flush()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, config Config) *Client {
+	t.Helper()
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func TestGetFileBytesIncomplete(t *testing.T) {
+	srv := truncatingServer(t, 1000, 100)
+	c := newTestClient(t, Config{BaseURL: srv.URL})
+
+	data, err := c.GetFileBytes("file-1")
+	if !errors.Is(err, ErrIncompleteDownload) {
+		t.Fatalf("err = %v, want ErrIncompleteDownload", err)
+	}
+	if data != nil {
+		t.Errorf("data = %d bytes, want nil", len(data))
+	}
+}
+
+func TestDownloadToFileIncomplete(t *testing.T) {
+	srv := truncatingServer(t, 1000, 100)
+	c := newTestClient(t, Config{BaseURL: srv.URL})
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.bin")
+
+	err := c.DownloadToFile("file-1", dest)
+	if !errors.Is(err, ErrIncompleteDownload) {
+		t.Fatalf("err = %v, want ErrIncompleteDownload", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		t.Errorf("left %s in the destination directory", e.Name())
+	}
+}
@@ -0,0 +1,96 @@
+package storagesdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DownloadResult is the per-file outcome of DownloadAll.
+type DownloadResult struct {
+	FileID string // File that was downloaded
+	Path   string // Local path written (empty on error)
+	Err    error  // Non-nil if downloading this file failed
+}
+
+// DownloadAll downloads many files into destDir with at most concurrency downloads in flight
+// (defaultBulkConcurrency when <= 0) and returns one result per file ID (in input order).
+// Each file is saved under its original name; names that collide with each other or with
+// existing files get a numeric suffix ("report-1.pdf"). Files are verified against the
+// stored hash when its algorithm is known (see FileItem.HashAlgorithm). Failures on
+// individual files do not abort the others.
+func (c *Client) DownloadAll(fileIDs []string, destDir string, concurrency int, opts ...CallOption) ([]DownloadResult, error) {
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+	if info, err := os.Stat(destDir); err != nil {
+		return nil, fmt.Errorf("failed to download files: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("failed to download files: %s is not a directory", destDir)
+	}
+
+	names := &nameReserver{dir: destDir, taken: make(map[string]bool)}
+	results := make([]DownloadResult, len(fileIDs))
+	forEachConcurrent(len(fileIDs), concurrency, func(i int) {
+		results[i].FileID = fileIDs[i]
+		info, err := c.GetFile(fileIDs[i], metadataOpts(opts)...)
+		if err != nil {
+			results[i].Err = err
+			return
+		}
+		dest := names.reserve(localFileName(info.Data.OriginalName, fileIDs[i]))
+		if err := c.DownloadToFile(fileIDs[i], dest, opts...); err != nil {
+			results[i].Err = err
+			return
+		}
+		// With client-side encryption the service hashes the ciphertext.
+		if c.encryption == nil {
+			if err := verifyFileHash(dest, &info.Data); err != nil {
+				os.Remove(dest)
+				results[i].Err = err
+				return
+			}
+		}
+		results[i].Path = dest
+	})
+	return results, nil
+}
+
+// localFileName returns a safe base name for saving a file locally, falling
+// back to the file ID for empty or special names.
+func localFileName(originalName, fileID string) string {
+	name := filepath.Base(strings.ReplaceAll(originalName, "\\", "/"))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return fileID
+	}
+	return name
+}
+
+// nameReserver hands out unique paths in dir, avoiding names already handed
+// out and files that exist on disk.
+type nameReserver struct {
+	dir   string
+	mu    sync.Mutex
+	taken map[string]bool
+}
+
+func (r *nameReserver) reserve(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	candidate := name
+	for n := 1; ; n++ {
+		if !r.taken[candidate] {
+			if _, err := os.Lstat(filepath.Join(r.dir, candidate)); os.IsNotExist(err) {
+				break
+			}
+		}
+		candidate = stem + "-" + strconv.Itoa(n) + ext
+	}
+	r.taken[candidate] = true
+	return filepath.Join(r.dir, candidate)
+}
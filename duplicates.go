@@ -0,0 +1,53 @@
+package storagesdk
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// renameDuplicates gives every file a name that is not used by an existing
+// file or by an earlier file of the same upload, appending " (n)" before the
+// extension. It returns the renamed files keyed by local path.
+func (c *Client) renameDuplicates(files []formFile, callOpts []CallOption) (map[string]string, error) {
+	var renamed map[string]string
+	used := make(map[string]bool, len(files))
+	for i := range files {
+		name := files[i].name
+		ext := filepath.Ext(name)
+		stem := strings.TrimSuffix(name, ext)
+		candidate := name
+		for n := 2; ; n++ {
+			if !used[candidate] {
+				exists, err := c.nameExists(candidate, callOpts)
+				if err != nil {
+					return nil, fmt.Errorf("check name %q: %w", candidate, err)
+				}
+				if !exists {
+					break
+				}
+			}
+			candidate = fmt.Sprintf("%s (%d)%s", stem, n, ext)
+		}
+		used[candidate] = true
+		if candidate != name {
+			if renamed == nil {
+				renamed = make(map[string]string)
+			}
+			renamed[files[i].path] = candidate
+			files[i].name = candidate
+		}
+	}
+	return renamed, nil
+}
+
+// nameExists reports whether a file with the given original name exists.
+func (c *Client) nameExists(originalName string, opts []CallOption) (bool, error) {
+	q := originalNameQuery(originalName)
+	q.Set("per_page", "1")
+	resp, err := c.ListFiles(q.Encode(), opts...)
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Data) > 0, nil
+}
@@ -0,0 +1,128 @@
+package storagesdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestEncryption(t *testing.T) EncryptionProvider {
+	t.Helper()
+	enc, err := NewAESGCMEncryption(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+func encryptBytes(t *testing.T, enc EncryptionProvider, plain []byte) []byte {
+	t.Helper()
+	sealed, err := io.ReadAll(enc.Encrypt(bytes.NewReader(plain)))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	return sealed
+}
+
+func testPlaintext(size int) []byte {
+	plain := make([]byte, size)
+	for i := range plain {
+		plain[i] = byte(i * 31)
+	}
+	return plain
+}
+
+func TestAESGCMRoundTrip(t *testing.T) {
+	enc := newTestEncryption(t)
+	for _, size := range []int{0, 1, aesGCMChunkSize, aesGCMChunkSize + 1, 3 * aesGCMChunkSize} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			plain := testPlaintext(size)
+			got, err := io.ReadAll(enc.Decrypt(bytes.NewReader(encryptBytes(t, enc, plain))))
+			if err != nil {
+				t.Fatalf("decrypt: %v", err)
+			}
+			if !bytes.Equal(got, plain) {
+				t.Errorf("round trip changed the content (%d bytes in, %d out)", size, len(got))
+			}
+		})
+	}
+}
+
+func TestAESGCMDecryptRejectsModifiedStreams(t *testing.T) {
+	enc := newTestEncryption(t)
+	sealed := encryptBytes(t, enc, testPlaintext(aesGCMChunkSize+1))
+	firstChunk := aesGCMHeaderSize + 5
+	tests := map[string][]byte{
+		"truncated inside a chunk": sealed[:len(sealed)-10],
+		"final chunk missing":      sealed[:firstChunk+aesGCMChunkSize+16],
+		"header only":              sealed[:aesGCMHeaderSize],
+		"tampered chunk":           flipByte(sealed, firstChunk+100),
+		"tampered flag":            flipByte(sealed, aesGCMHeaderSize),
+		"trailing data":            append(append([]byte(nil), sealed...), 0),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := io.ReadAll(enc.Decrypt(bytes.NewReader(data)))
+			if !errors.Is(err, ErrDecryption) {
+				t.Errorf("err = %v, want ErrDecryption", err)
+			}
+		})
+	}
+}
+
+func flipByte(data []byte, i int) []byte {
+	out := append([]byte(nil), data...)
+	out[i] ^= 0x01
+	return out
+}
+
+func TestAESGCMDetection(t *testing.T) {
+	enc := newTestEncryption(t)
+	d := enc.(EncryptionDetector)
+	header := func(data []byte) []byte { return data[:min(len(data), d.HeaderSize())] }
+
+	for _, size := range []int{0, aesGCMChunkSize + 1} {
+		if sealed := encryptBytes(t, enc, testPlaintext(size)); !d.IsEncrypted(header(sealed)) {
+			t.Errorf("ciphertext of %d bytes not detected as encrypted", size)
+		}
+	}
+	plain := append([]byte(aesGCMMagic), testPlaintext(200)...)
+	if d.IsEncrypted(header(plain)) {
+		t.Error(`plaintext starting with "SSE1" detected as encrypted`)
+	}
+	other, err := NewAESGCMEncryption(bytes.Repeat([]byte{8}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.IsEncrypted(header(encryptBytes(t, other, testPlaintext(10)))) {
+		t.Error("content encrypted with another key detected as encrypted")
+	}
+}
+
+func TestDownloadFileLeavesMagicPlaintextAlone(t *testing.T) {
+	enc := newTestEncryption(t)
+	plain := append([]byte(aesGCMMagic), " not actually encrypted"...)
+	sealed := encryptBytes(t, enc, []byte("secret"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apiPathPrefix+"/files/sealed" {
+			w.Write(sealed)
+			return
+		}
+		w.Write(plain)
+	}))
+	defer srv.Close()
+	c := newTestClient(t, Config{BaseURL: srv.URL, Encryption: enc})
+
+	got, err := c.GetFileBytes("plain")
+	if err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("plain file = %q, %v; want %q", got, err, plain)
+	}
+	got, err = c.GetFileBytes("sealed")
+	if err != nil || string(got) != "secret" {
+		t.Errorf("encrypted file = %q, %v; want %q", got, err, "secret")
+	}
+}
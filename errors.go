@@ -0,0 +1,168 @@
+package storagesdk
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// ErrIncompleteDownload is returned when a download ends before the advertised
+// Content-Length was received. Partial data is never returned as a success.
+var ErrIncompleteDownload = errors.New("incomplete download")
+
+func incompleteDownloadError(received, expected int64) error {
+	return fmt.Errorf("%w: received %d of %d bytes", ErrIncompleteDownload, received, expected)
+}
+
+// RedirectError is returned when an API call answers with a redirect that was
+// not followed: redirects are disabled (Config.DisableRedirects) or the
+// response could not be followed, as with 300 Multiple Choices. It usually
+// points at a wrong BaseURL or a misconfigured proxy.
+type RedirectError struct {
+	StatusCode int    // HTTP status code (3xx)
+	Location   string // redirect target resolved against the request URL (empty if none)
+}
+
+// Error implements the error interface
+func (e *RedirectError) Error() string {
+	if e.Location == "" {
+		return fmt.Sprintf("storage service redirected with status %d without a location (check BaseURL and proxy configuration)", e.StatusCode)
+	}
+	return fmt.Sprintf("storage service redirected with status %d to %s (check BaseURL and proxy configuration)", e.StatusCode, e.Location)
+}
+
+// redirectError returns a *RedirectError for a 3xx response other than 304
+// Not Modified, or nil.
+func redirectError(resp *http.Response) error {
+	if resp.StatusCode < 300 || resp.StatusCode > 399 || resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	e := &RedirectError{StatusCode: resp.StatusCode}
+	if loc, err := resp.Location(); err == nil {
+		e.Location = loc.Redacted()
+	}
+	return e
+}
+
+// ErrTruncatedResponse is returned when a JSON response body ends before the
+// document is complete, typically because a server or proxy timed out and
+// closed the connection mid-response.
+var ErrTruncatedResponse = errors.New("server returned truncated response")
+
+// decodeJSON decodes a JSON response body into result, reporting a body cut
+// off mid-document as ErrTruncatedResponse with the number of bytes received.
+func decodeJSON(body io.Reader, result interface{}) error {
+	counter := &countingReader{r: body}
+	err := json.NewDecoder(counter).Decode(result)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w after %d bytes (check server and proxy timeouts): %w", ErrTruncatedResponse, counter.n, err)
+	}
+	return err
+}
+
+// decodeResponse decodes a success response into result. Responses without a
+// body (204 No Content, Content-Length 0 or an empty stream) and plain-text
+// confirmations leave result untouched. A text/plain body that starts like a
+// JSON object or array is still decoded, for servers that mislabel JSON.
+// Field names are checked and mapped as described for unmarshalResponse.
+func (c *Client) decodeResponse(resp *http.Response, result interface{}) error {
+	if resp.StatusCode == http.StatusNoContent || resp.ContentLength == 0 {
+		return nil
+	}
+	body := bufio.NewReader(resp.Body)
+	first, err := body.Peek(1)
+	if err == io.EOF {
+		return nil
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "text/plain" && err == nil && first[0] != '{' && first[0] != '[' {
+		return nil
+	}
+	var raw json.RawMessage
+	if err := decodeJSON(body, &raw); err != nil {
+		return err
+	}
+	return c.unmarshalResponse(raw, result)
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ErrNotSupported is returned when the storage service does not implement an
+// optional endpoint (versions, purge, ...). The underlying *APIError is wrapped too.
+var ErrNotSupported = errors.New("not supported by storage service")
+
+// asNotSupported maps responses for routes the server does not know (405, 501,
+// or a 404 whose body is not a JSON API error) to ErrNotSupported.
+func asNotSupported(err error) error {
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		return err
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusMethodNotAllowed, apiErr.StatusCode == http.StatusNotImplemented:
+	case apiErr.StatusCode == http.StatusNotFound && !json.Valid([]byte(apiErr.Body)):
+	default:
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrNotSupported, apiErr)
+}
+
+// ErrAlreadyExists is returned when a create-only operation targets a file that
+// already exists. The underlying *APIError is wrapped too.
+var ErrAlreadyExists = errors.New("file already exists")
+
+// asAlreadyExists maps 409 Conflict and 412 Precondition Failed to ErrAlreadyExists.
+func asAlreadyExists(err error) error {
+	apiErr, ok := IsAPIError(err)
+	if !ok || (apiErr.StatusCode != http.StatusConflict && apiErr.StatusCode != http.StatusPreconditionFailed) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrAlreadyExists, apiErr)
+}
+
+// ErrNotShareable is returned by GetShareLink for files that are not public
+// (see SetFilePublic). The underlying *APIError is wrapped too when the
+// service refused the request.
+var ErrNotShareable = errors.New("file is not shareable")
+
+// asNotShareable maps 403 Forbidden and 409 Conflict to ErrNotShareable.
+func asNotShareable(err error) error {
+	apiErr, ok := IsAPIError(err)
+	if !ok || (apiErr.StatusCode != http.StatusForbidden && apiErr.StatusCode != http.StatusConflict) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrNotShareable, apiErr)
+}
+
+// ErrNotModified is returned by conditional requests when the resource still
+// matches the entity tag the caller already has. The underlying *APIError is
+// wrapped too when the service answered 304 Not Modified.
+var ErrNotModified = errors.New("not modified")
+
+// asNotModified maps 304 Not Modified to ErrNotModified.
+func asNotModified(err error) error {
+	apiErr, ok := IsAPIError(err)
+	if !ok || apiErr.StatusCode != http.StatusNotModified {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrNotModified, apiErr)
+}
+
+// ErrNotFound is returned by lookups that match no file.
+var ErrNotFound = errors.New("file not found")
+
+// ErrMultipleMatches is returned by lookups expecting one file when several match.
+var ErrMultipleMatches = errors.New("multiple files match")
@@ -0,0 +1,74 @@
+package storagesdk
+
+import "strings"
+
+// parseETag splits an entity tag into its opaque tag and weak flag.
+// Both `W/"abc"` and `"abc"` are accepted; unquoted values are tolerated.
+func parseETag(etag string) (opaque string, weak bool) {
+	etag = strings.TrimSpace(etag)
+	if strings.HasPrefix(etag, "W/") {
+		weak = true
+		etag = etag[2:]
+	}
+	return etag, weak
+}
+
+// StrongETagMatch reports whether two entity tags match using the strong
+// comparison of RFC 7232 section 2.3.2: neither tag may be weak and the opaque
+// tags must be identical. Use it for If-Match and range requests.
+func StrongETagMatch(a, b string) bool {
+	oa, wa := parseETag(a)
+	ob, wb := parseETag(b)
+	return !wa && !wb && oa != "" && oa == ob
+}
+
+// WeakETagMatch reports whether two entity tags match using the weak
+// comparison of RFC 7232 section 2.3.2: the opaque tags must be identical,
+// regardless of either tag being weak. Use it for If-None-Match and caching.
+func WeakETagMatch(a, b string) bool {
+	oa, _ := parseETag(a)
+	ob, _ := parseETag(b)
+	return oa != "" && oa == ob
+}
+
+// ETagListMatch reports whether etag matches any entry of an If-Match or
+// If-None-Match header value (a comma-separated list or "*"). strong selects
+// the strong comparison function; otherwise the weak one is used.
+func ETagListMatch(list, etag string, strong bool) bool {
+	list = strings.TrimSpace(list)
+	if list == "" || etag == "" {
+		return false
+	}
+	if list == "*" {
+		return true
+	}
+	for _, candidate := range splitETagList(list) {
+		if strong && StrongETagMatch(candidate, etag) {
+			return true
+		}
+		if !strong && WeakETagMatch(candidate, etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitETagList splits a comma-separated entity-tag list, keeping commas that
+// appear inside quoted opaque tags.
+func splitETagList(list string) []string {
+	var tags []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(list); i++ {
+		switch list[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				tags = append(tags, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(tags, list[start:])
+}
@@ -0,0 +1,54 @@
+package storagesdk
+
+import (
+	"sort"
+	"strings"
+)
+
+// normalizeExtension returns ext in the ".jpg" form: lower case with one leading dot.
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimLeft(strings.TrimSpace(ext), "."))
+	if ext == "" {
+		return ""
+	}
+	return "." + ext
+}
+
+// AllowedExtensions returns the extensions the service accepts (the keys of
+// GetFileLimits' Extensions), normalized to lower case with a leading dot and
+// sorted, e.g. for an <input accept> attribute. The list is derived from the
+// global limits cached by GetFileLimitsFor, so it is refreshed every five
+// minutes.
+func (c *Client) AllowedExtensions(opts ...CallOption) ([]string, error) {
+	limits, err := c.GetFileLimitsFor(LimitsContext{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(limits.Data.Extensions))
+	exts := make([]string, 0, len(limits.Data.Extensions))
+	for k := range limits.Data.Extensions {
+		if ext := normalizeExtension(k); ext != "" && !set[ext] {
+			set[ext] = true
+			exts = append(exts, ext)
+		}
+	}
+	sort.Strings(exts)
+	return exts, nil
+}
+
+// IsExtensionAllowed reports whether the service accepts files with extension
+// ext ("jpg", ".JPG" and "jpg" are equivalent). It uses the cached
+// AllowedExtensions list; when the service lists no extensions, every extension
+// is allowed. It returns false if the list cannot be fetched.
+func (c *Client) IsExtensionAllowed(ext string, opts ...CallOption) bool {
+	exts, err := c.AllowedExtensions(opts...)
+	if err != nil {
+		return false
+	}
+	if len(exts) == 0 {
+		return true
+	}
+	ext = normalizeExtension(ext)
+	i := sort.SearchStrings(exts, ext)
+	return i < len(exts) && exts[i] == ext
+}
@@ -0,0 +1,131 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filterOperators are the comparison operators understood by the list filters
+// (sent as <field>_<op>=<value>).
+var filterOperators = map[string]bool{
+	"eq": true, "ne": true, "gt": true, "gte": true, "lt": true, "lte": true, "like": true, "in": true,
+}
+
+// FileQuery builds a ListFiles query fluently:
+//
+//	resp, err := client.Files().
+//		Where("status", "eq", "active").
+//		And("fileSize", "gte", "1000000").
+//		OrderBy("createdAt", "desc").
+//		Page(2).
+//		List()
+//
+// Field names may be given as in FileItem JSON (camelCase) or as the API's
+// snake_case. Invalid operators or sort directions are reported by List, All
+// and Query rather than by the chained calls.
+type FileQuery struct {
+	c      *Client
+	values url.Values
+	err    error
+}
+
+// Files starts a fluent query over the client's files.
+func (c *Client) Files() *FileQuery {
+	return &FileQuery{c: c, values: url.Values{}}
+}
+
+// Where adds the filter field <op> value. op is one of eq, ne, gt, gte, lt,
+// lte, like or in (comma-separated values).
+func (q *FileQuery) Where(field, op, value string) *FileQuery {
+	op = strings.ToLower(op)
+	if !filterOperators[op] {
+		q.setErr(fmt.Errorf("unknown filter operator %q for field %q", op, field))
+		return q
+	}
+	if field == "" {
+		q.setErr(fmt.Errorf("filter field is required"))
+		return q
+	}
+	q.values.Add(snakeCase(field)+"_"+op, value)
+	return q
+}
+
+// And is Where, for readability in chains.
+func (q *FileQuery) And(field, op, value string) *FileQuery {
+	return q.Where(field, op, value)
+}
+
+// OrderBy sorts results by field in direction "asc" or "desc".
+func (q *FileQuery) OrderBy(field, direction string) *FileQuery {
+	direction = strings.ToLower(direction)
+	if direction != "asc" && direction != "desc" {
+		q.setErr(fmt.Errorf("invalid sort direction %q, want asc or desc", direction))
+		return q
+	}
+	q.values.Set(sortByParam, snakeCase(field))
+	q.values.Set(sortOrderParam, direction)
+	return q
+}
+
+// Page selects the page to list (1-based).
+func (q *FileQuery) Page(page int) *FileQuery {
+	q.values.Set("page", strconv.Itoa(page))
+	return q
+}
+
+// PerPage sets the page size.
+func (q *FileQuery) PerPage(n int) *FileQuery {
+	q.values.Set("per_page", strconv.Itoa(n))
+	return q
+}
+
+// Query returns the compiled, escaped query string for ListFiles.
+func (q *FileQuery) Query() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	return q.values.Encode(), nil
+}
+
+// List fetches the selected page.
+func (q *FileQuery) List(opts ...CallOption) (*ListFilesResponse, error) {
+	query, err := q.Query()
+	if err != nil {
+		return nil, err
+	}
+	return q.c.ListFiles(query, opts...)
+}
+
+// All fetches every matching file across all pages (see ListAllFiles).
+func (q *FileQuery) All(opts IteratorOptions) ([]FileItem, error) {
+	query, err := q.Query()
+	if err != nil {
+		return nil, err
+	}
+	return q.c.ListAllFiles(query, opts)
+}
+
+func (q *FileQuery) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// snakeCase converts a camelCase field name ("fileSize") to the API's
+// snake_case ("file_size"); snake_case input is returned unchanged.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
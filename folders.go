@@ -0,0 +1,64 @@
+package storagesdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CreateFolderRequest represents the request body for creating a folder
+type CreateFolderRequest struct {
+	Path    string `json:"path"`
+	Parents bool   `json:"parents"` // create missing intermediate folders
+}
+
+// CreateFolder creates folderPath (e.g. "invoices/2024/q1") including any missing
+// intermediate folders, placed under Config.PathPrefix when one is configured. An
+// existing folder is not an error. It returns an error wrapping ErrNotSupported if
+// the service has no folder endpoint.
+func (c *Client) CreateFolder(folderPath string, opts ...CallOption) error {
+	cleaned, err := cleanFolderPath(folderPath)
+	if err != nil {
+		return err
+	}
+	return c.createFolder(c.uploadFolder(cleaned), opts)
+}
+
+// createFolder creates a folder given its full path (prefix already applied).
+func (c *Client) createFolder(fullPath string, opts []CallOption) error {
+	req := CreateFolderRequest{Path: fullPath, Parents: true}
+	err := c.do(http.MethodPost, apiPathPrefix+"/folders", req, []int{http.StatusOK, http.StatusCreated}, nil, "failed to create folder", opts...)
+	if errors.Is(asAlreadyExists(err), ErrAlreadyExists) {
+		return nil
+	}
+	return asNotSupported(err)
+}
+
+// isMissingFolder reports whether an upload error looks like a rejected
+// destination folder: a JSON 404 (the route exists) or a 422.
+func isMissingFolder(err error) bool {
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound:
+		return json.Valid([]byte(apiErr.Body))
+	case http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+// createParentsAndRetry handles a failed upload with CreateParents set: it
+// creates the folder and reports whether the upload should be retried.
+func (c *Client) createParentsAndRetry(folder string, uploadErr error, callOpts []CallOption) (bool, error) {
+	if folder == "" || !isMissingFolder(uploadErr) {
+		return false, uploadErr
+	}
+	if err := c.createFolder(folder, callOpts); err != nil {
+		return false, fmt.Errorf("failed to upload files: folder %q could not be created (the service neither auto-created it nor accepted a folder creation request): %w", folder, errors.Join(uploadErr, err))
+	}
+	return true, nil
+}
@@ -0,0 +1,76 @@
+package storagesdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GroupFilesResponse represents the response from the file aggregation endpoint
+type GroupFilesResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Status  int              `json:"status"`
+	Data    map[string]int64 `json:"data"`
+}
+
+// GroupFilesBy counts files matching queryString grouped by field: "fileType", "status",
+// "mimeType", "extension" or a metadata key as "metadata.<key>". It uses the service's
+// aggregation endpoint when available; otherwise it pages through all matching files and
+// groups them client-side, which costs one request per page. Files without a value for the
+// field are counted under "".
+func (c *Client) GroupFilesBy(field, queryString string, opts ...CallOption) (map[string]int64, error) {
+	value, err := groupValueFunc(field)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{"group_by": {field}}.Encode()
+	if scoped := c.scopeQuery(queryString); scoped != "" {
+		q += "&" + scoped
+	}
+	var result GroupFilesResponse
+	err = c.do(http.MethodGet, apiPathPrefix+"/files/stats?"+q, nil, []int{http.StatusOK}, &result, "failed to group files", opts...)
+	if err == nil {
+		return result.Data, nil
+	}
+	if err = asNotSupported(err); !errors.Is(err, ErrNotSupported) {
+		return nil, err
+	}
+
+	it := c.NewFileIterator(queryString, IteratorOptions{Context: newCallOptions(opts).context()})
+	defer it.Close()
+	counts := make(map[string]int64)
+	for file, ok := it.Next(); ok; file, ok = it.Next() {
+		counts[value(file)]++
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to group files: %w", err)
+	}
+	return counts, nil
+}
+
+// groupValueFunc returns an accessor for a groupable field.
+func groupValueFunc(field string) (func(FileItem) string, error) {
+	switch field {
+	case "fileType", "file_type":
+		return func(f FileItem) string { return f.FileType }, nil
+	case "status":
+		return func(f FileItem) string { return f.Status }, nil
+	case "mimeType", "mime_type":
+		return func(f FileItem) string { return f.MimeType }, nil
+	case "extension":
+		return func(f FileItem) string { return f.Extension }, nil
+	}
+	if key, ok := strings.CutPrefix(field, "metadata."); ok && key != "" {
+		return func(f FileItem) string {
+			if v, ok := f.Metadata[key]; ok && v != nil {
+				return fmt.Sprint(v)
+			}
+			return ""
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported group field %q", field)
+}
@@ -0,0 +1,165 @@
+package storagesdk
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// HashMismatchError is returned when content hashed by the SDK does not match
+// the hash recorded by the storage service.
+type HashMismatchError struct {
+	FileID    string // ID of the file on the server (if known)
+	Name      string // Original file name
+	Expected  string // Hash computed by the SDK
+	Actual    string // Hash reported by the server
+	Algorithm string // Hash algorithm, e.g. "sha256"
+}
+
+// Error implements the error interface
+func (e *HashMismatchError) Error() string {
+	return fmt.Sprintf("hash mismatch for %s (file %s): expected %s, got %s", e.Name, e.FileID, e.Expected, e.Actual)
+}
+
+// hashAlgorithms maps the length of a hex-encoded digest to its algorithm.
+var hashAlgorithms = map[int]struct {
+	name string
+	new  func() hash.Hash
+}{
+	md5.Size * 2:    {"md5", md5.New},
+	sha1.Size * 2:   {"sha1", sha1.New},
+	sha256.Size * 2: {"sha256", sha256.New},
+	sha512.Size * 2: {"sha512", sha512.New},
+}
+
+// HashAlgorithm returns the algorithm of Hash as detected from its length
+// ("md5", "sha1", "sha256" or "sha512"), or "" when Hash is empty, not hex
+// or of an unknown length.
+func (f FileItem) HashAlgorithm() string {
+	if _, err := hex.DecodeString(f.Hash); err != nil {
+		return ""
+	}
+	return hashAlgorithms[len(f.Hash)].name
+}
+
+// hashingBody hashes a download as it is read and fails the final read with
+// a *HashMismatchError when the content does not match item.Hash.
+type hashingBody struct {
+	io.ReadCloser
+	h    hash.Hash
+	item *FileItem
+}
+
+// newHashingBody wraps body for verification against item.Hash, whose
+// algorithm must be known (see FileItem.HashAlgorithm).
+func newHashingBody(body io.ReadCloser, item *FileItem) *hashingBody {
+	return &hashingBody{ReadCloser: body, h: hashAlgorithms[len(item.Hash)].new(), item: item}
+}
+
+func (b *hashingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.h.Write(p[:n])
+	if err == io.EOF {
+		if sum := hex.EncodeToString(b.h.Sum(nil)); !hashEqual(sum, b.item.Hash) {
+			return n, &HashMismatchError{FileID: b.item.ID, Name: b.item.OriginalName, Expected: sum, Actual: b.item.Hash, Algorithm: b.item.HashAlgorithm()}
+		}
+	}
+	return n, err
+}
+
+// DownloadVerified is DownloadFile with WithHashVerification: the content is
+// hashed while it is read, with the algorithm detected from the stored hash
+// (see FileItem.HashAlgorithm), and the read that reaches the end of the body
+// fails with a *HashMismatchError if the content is corrupted. Consume the
+// body fully before trusting it. It returns an error if the service has no
+// hash of a known algorithm for the file. Caller must close resp.Body.
+func (c *Client) DownloadVerified(fileID string, opts ...CallOption) (*http.Response, error) {
+	return c.DownloadFile(fileID, withOpts(opts, WithHashVerification())...)
+}
+
+// hashFile returns the hex-encoded digest of a local file computed with
+// newHash (sha256.New for the hashes sent with uploads).
+func hashFile(path string, newHash func() hash.Hash) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := newHash()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashesComparable reports whether two hex hashes were produced by the same
+// algorithm (same digest length), so a difference means different content.
+func hashesComparable(a, b string) bool {
+	return a != "" && b != "" && len(a) == len(b)
+}
+
+func hashEqual(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// verifyFileHash compares the digest of a downloaded local file with the hash
+// the service recorded for it, using the algorithm detected from that hash
+// (see FileItem.HashAlgorithm). Hashes of an unknown algorithm are not checked.
+func verifyFileHash(path string, item *FileItem) error {
+	name := item.HashAlgorithm()
+	if name == "" {
+		return nil
+	}
+	sum, err := hashFile(path, hashAlgorithms[len(item.Hash)].new)
+	if err != nil {
+		return err
+	}
+	if !hashEqual(sum, item.Hash) {
+		return &HashMismatchError{FileID: item.ID, Name: item.OriginalName, Expected: sum, Actual: item.Hash, Algorithm: name}
+	}
+	return nil
+}
+
+// VerifyFile downloads a file's content without storing it and reports whether
+// it matches the hash the service recorded, for integrity audits. The
+// algorithm (MD5, SHA-1, SHA-256 or SHA-512) is detected from the stored hash
+// (see FileItem.HashAlgorithm). The stored bytes are hashed as served, so with
+// client-side encryption the ciphertext is checked (which is what the service
+// hashes). It returns an error if the download fails or the service has no
+// hash of a known algorithm for the file. WithProgress reports the bytes
+// streamed.
+func (c *Client) VerifyFile(fileID string, opts ...CallOption) (bool, error) {
+	if fileID == "" {
+		return false, fmt.Errorf("file ID is required")
+	}
+	info, err := c.GetFile(fileID, metadataOpts(opts)...)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify file: %w", err)
+	}
+	stored := info.Data.Hash
+	if info.Data.HashAlgorithm() == "" {
+		return false, fmt.Errorf("failed to verify file: file %s has no hash of a known algorithm", fileID)
+	}
+	resp, err := c.doRequest(http.MethodGet, apiPathPrefix+"/files/"+pathSeg(fileID)+"?download=true", nil, opts...)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, c.apiError(resp.StatusCode, body)
+	}
+	h := hashAlgorithms[len(stored)].new()
+	if _, err := copyBody(h, resp, newCallOptions(opts).progress); err != nil {
+		return false, fmt.Errorf("failed to verify file: %w", err)
+	}
+	return hashEqual(hex.EncodeToString(h.Sum(nil)), stored), nil
+}
@@ -0,0 +1,208 @@
+package storagesdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// IteratorOptions configures a FileIterator.
+type IteratorOptions struct {
+	// Prefetch fetches the next page in the background while the caller
+	// processes the current one, smoothing latency at page boundaries.
+	Prefetch bool
+
+	// Context cancels page fetches and stops the prefetcher (optional).
+	Context context.Context
+
+	// MaxResults stops iteration after this many files, even mid-page, and
+	// fetches no further pages (0 means no limit).
+	MaxResults int
+}
+
+// FileIterator walks all pages of a file listing.
+//
+//	it := client.NewFileIterator("status_eq=active", storagesdk.IteratorOptions{Prefetch: true})
+//	defer it.Close()
+//	for file, ok := it.Next(); ok; file, ok = it.Next() {
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type FileIterator struct {
+	c        *Client
+	query    url.Values
+	opts     IteratorOptions
+	ctx      context.Context
+	cancel   context.CancelFunc
+	callOpts []CallOption // applied to every page request (context aside)
+
+	items    []FileItem
+	pos      int
+	returned int  // files returned so far (for MaxResults)
+	nextPage int  // page to request next; 0 lets the server pick the first page
+	last     bool // the current page is the final one
+	started  bool
+	err      error
+
+	pages chan pageResult // prefetched pages (Prefetch only)
+}
+
+type pageResult struct {
+	items []FileItem
+	next  int // next page number, 0 when there are no more pages
+	err   error
+}
+
+// NewFileIterator returns an iterator over all files matching queryString (same syntax as
+// ListFiles). Pages are requested using the response's pagination metadata; a response
+// without pagination is treated as the only page. Call Close when abandoning iteration early.
+func (c *Client) NewFileIterator(queryString string, opts IteratorOptions) *FileIterator {
+	parent := opts.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	it := &FileIterator{c: c, opts: opts, ctx: ctx, cancel: cancel}
+
+	query, err := url.ParseQuery(queryString)
+	if err != nil {
+		it.err = fmt.Errorf("invalid query string: %w", err)
+		return it
+	}
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		it.nextPage = p
+	}
+	it.query = query
+	return it
+}
+
+// ListAllFiles returns every file matching queryString across all pages, or at most
+// opts.MaxResults files when set. Responses without pagination metadata (non-paginated
+// endpoints) are treated as the only page.
+func (c *Client) ListAllFiles(queryString string, opts IteratorOptions) ([]FileItem, error) {
+	return c.NewFileIterator(queryString, opts).All()
+}
+
+// Next returns the next file. It returns false when iteration is complete or an error
+// occurred; check Err afterwards.
+func (it *FileIterator) Next() (FileItem, bool) {
+	if it.opts.MaxResults > 0 && it.returned >= it.opts.MaxResults {
+		it.Close()
+		return FileItem{}, false
+	}
+	for it.pos >= len(it.items) {
+		if it.err != nil || it.last {
+			return FileItem{}, false
+		}
+		pr := it.fetch()
+		if pr.err != nil {
+			it.err = pr.err
+			return FileItem{}, false
+		}
+		it.items, it.pos = pr.items, 0
+		it.nextPage = pr.next
+		it.last = pr.next == 0
+	}
+	item := it.items[it.pos]
+	it.pos++
+	it.returned++
+	return item, true
+}
+
+// All returns the files not yet returned by Next, fetching all remaining
+// pages, and closes the iterator. The first API error is returned instead of
+// partial results.
+func (it *FileIterator) All() ([]FileItem, error) {
+	defer it.Close()
+	var files []FileItem
+	for file, ok := it.Next(); ok; file, ok = it.Next() {
+		files = append(files, file)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Err returns the first error encountered during iteration.
+func (it *FileIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iterator and any background prefetching.
+func (it *FileIterator) Close() {
+	it.cancel()
+}
+
+// fetch returns the next page, either directly or from the prefetcher.
+func (it *FileIterator) fetch() pageResult {
+	if !it.opts.Prefetch {
+		return it.fetchPage(it.nextPage)
+	}
+	if !it.started {
+		it.started = true
+		it.pages = make(chan pageResult, 1)
+		go it.prefetch(it.nextPage)
+	}
+	select {
+	case pr := <-it.pages:
+		return pr
+	case <-it.ctx.Done():
+		return pageResult{err: it.ctx.Err()}
+	}
+}
+
+// prefetch fetches pages ahead of the consumer until the last page, an error, or cancellation.
+func (it *FileIterator) prefetch(page int) {
+	for {
+		pr := it.fetchPage(page)
+		select {
+		case it.pages <- pr:
+		case <-it.ctx.Done():
+			return
+		}
+		if pr.err != nil || pr.next == 0 {
+			return
+		}
+		page = pr.next
+	}
+}
+
+func (it *FileIterator) fetchPage(page int) pageResult {
+	query := url.Values{}
+	for k, v := range it.query {
+		query[k] = v
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	resp, err := it.c.ListFiles(query.Encode(), withOpts(it.callOpts, WithContext(it.ctx))...)
+	if err != nil {
+		return pageResult{err: err}
+	}
+	return pageResult{items: resp.Data, next: nextPageNumber(resp.Pagination, page)}
+}
+
+// nextPageNumber returns the page following the current one, or 0 when there is none.
+// A nil Pagination (non-paginated endpoint) means the response was the only page. A response
+// without a page number to a request without one is the first page.
+func nextPageNumber(p *Pagination, requested int) int {
+	if p == nil || !p.HasNext {
+		return 0
+	}
+	current := p.Page
+	if current == 0 {
+		current = max(requested, 1)
+	}
+	next := current + 1
+	if p.NextPage != nil {
+		next = *p.NextPage
+	}
+	if next <= current {
+		return 0
+	}
+	return next
+}
@@ -0,0 +1,65 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestListAllFilesWithoutPagination(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("prefetch=%t", prefetch), func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"data":[{"id":"a","originalName":"a.txt"},{"id":"b","originalName":"b.txt"}]}`)
+			}))
+			defer srv.Close()
+			c := newTestClient(t, Config{BaseURL: srv.URL})
+
+			files, err := c.ListAllFiles("", IteratorOptions{Prefetch: prefetch})
+			if err != nil {
+				t.Fatalf("ListAllFiles: %v", err)
+			}
+			if len(files) != 2 || files[0].ID != "a" || files[1].ID != "b" {
+				t.Errorf("files = %+v, want a and b", files)
+			}
+			if n := requests.Load(); n != 1 {
+				t.Errorf("made %d requests, want 1", n)
+			}
+		})
+	}
+}
+
+func TestListAllFilesWithoutPageNumber(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch page := r.URL.Query().Get("page"); page {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"a"}],"pagination":{"perPage":1,"total":2,"totalPages":2,"hasNext":true}}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":"b"}],"pagination":{"perPage":1,"total":2,"totalPages":2,"hasNext":false}}`)
+		default:
+			t.Errorf("unexpected request for page %s", page)
+			fmt.Fprint(w, `{"data":[]}`)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, Config{BaseURL: srv.URL})
+
+	files, err := c.ListAllFiles("", IteratorOptions{})
+	if err != nil {
+		t.Fatalf("ListAllFiles: %v", err)
+	}
+	if len(files) != 2 || files[0].ID != "a" || files[1].ID != "b" {
+		t.Errorf("files = %+v, want a and b", files)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("made %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,109 @@
+package storagesdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrFieldNameMismatch is returned when a response uses snake_case field names
+// ("original_name") while the client expects camelCase, which would otherwise
+// decode into silently zero-valued results. Set Config.SnakeCaseJSON for such
+// deployments.
+var ErrFieldNameMismatch = errors.New("response uses snake_case field names (set Config.SnakeCaseJSON)")
+
+// snakeCaseMarkers pairs snake_case keys with the camelCase keys the SDK
+// decodes, for detecting responses from snake_case deployments.
+var snakeCaseMarkers = [][2]string{
+	{`"original_name"`, `"originalName"`},
+	{`"stored_name"`, `"storedName"`},
+	{`"file_size"`, `"fileSize"`},
+	{`"upload_id"`, `"uploadId"`},
+	{`"per_page"`, `"perPage"`},
+}
+
+// unmarshalResponse decodes a JSON response document into result. With
+// Config.SnakeCaseJSON, object keys are rewritten to camelCase first;
+// otherwise a document that looks snake_case fails with ErrFieldNameMismatch.
+func (c *Client) unmarshalResponse(raw []byte, result interface{}) error {
+	if c.snakeCaseJSON {
+		converted, err := camelCaseKeys(raw)
+		if err != nil {
+			return err
+		}
+		raw = converted
+	} else if err := checkFieldNames(raw); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, result)
+}
+
+// checkFieldNames reports ErrFieldNameMismatch when raw has a snake_case key
+// the SDK knows in camelCase and not the camelCase key itself.
+func checkFieldNames(raw []byte) error {
+	for _, m := range snakeCaseMarkers {
+		if bytes.Contains(raw, []byte(m[0])) && !bytes.Contains(raw, []byte(m[1])) {
+			return fmt.Errorf("%w: found %s", ErrFieldNameMismatch, m[0])
+		}
+	}
+	return nil
+}
+
+// camelCaseKeys rewrites the object keys of a JSON document from snake_case
+// to camelCase. User metadata is kept as is.
+func camelCaseKeys(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(renameKeys(doc))
+}
+
+func renameKeys(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if k == "metadata" {
+				out[k] = item
+				continue
+			}
+			out[camelCase(k)] = renameKeys(item)
+		}
+		return out
+	case []interface{}:
+		for i, item := range val {
+			val[i] = renameKeys(item)
+		}
+		return val
+	}
+	return v
+}
+
+// camelCase converts a snake_case field name ("file_size") to camelCase
+// ("fileSize"), the inverse of snakeCase. Leading and trailing underscores
+// are kept.
+func camelCase(s string) string {
+	if !strings.Contains(s, "_") {
+		return s
+	}
+	var b strings.Builder
+	upper := false
+	for i, r := range s {
+		switch {
+		case r == '_' && b.Len() > 0 && i+1 < len(s) && s[i+1] != '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// fileLimitsTTL is how long GetFileLimitsFor reuses fetched limits.
+const fileLimitsTTL = 5 * time.Minute
+
+// LimitsContext selects the upload context whose limits GetFileLimitsFor
+// returns, for services that apply different limits per destination.
+type LimitsContext struct {
+	Folder   string // destination folder, placed under Config.PathPrefix like UploadOptions.Folder
+	Category string // application-defined upload category, e.g. "public"
+}
+
+// limitsCache holds limits fetched by GetFileLimitsFor, keyed by context.
+type limitsCache struct {
+	mu       sync.Mutex
+	entries  map[LimitsContext]cachedLimits
+	inflight map[LimitsContext]chan struct{} // closed when the fetch for a context ends
+}
+
+type cachedLimits struct {
+	limits    *GetFileLimitsResponse
+	fetchedAt time.Time
+}
+
+// GetFileLimitsFor returns the file limits that apply in an upload context,
+// sending the folder and category as query parameters (GET
+// /files/limits?folder=...&category=...). Limits are cached per context for
+// five minutes; the zero LimitsContext returns the global limits. Services
+// without per-context limits ignore the parameters. The returned response is
+// shared with the cache and must not be modified. Concurrent calls for the
+// same context share one request, without blocking calls for other contexts.
+func (c *Client) GetFileLimitsFor(lc LimitsContext, opts ...CallOption) (*GetFileLimitsResponse, error) {
+	ctx := newCallOptions(opts).context()
+	var done chan struct{}
+	for done == nil {
+		c.limits.mu.Lock()
+		if e, ok := c.limits.entries[lc]; ok && time.Since(e.fetchedAt) < fileLimitsTTL {
+			c.limits.mu.Unlock()
+			return e.limits, nil
+		}
+		wait, busy := c.limits.inflight[lc]
+		if !busy {
+			if c.limits.inflight == nil {
+				c.limits.inflight = make(map[LimitsContext]chan struct{})
+			}
+			done = make(chan struct{})
+			c.limits.inflight[lc] = done
+		}
+		c.limits.mu.Unlock()
+		if busy {
+			// Another call is fetching this context; use its result, or
+			// fetch again if it failed.
+			select {
+			case <-wait:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to get file limits: %w", ctx.Err())
+			}
+		}
+	}
+
+	result, err := c.fetchFileLimits(lc, opts)
+	c.limits.mu.Lock()
+	defer c.limits.mu.Unlock()
+	delete(c.limits.inflight, lc)
+	close(done)
+	if err != nil {
+		return nil, err
+	}
+	if c.limits.entries == nil {
+		c.limits.entries = make(map[LimitsContext]cachedLimits)
+	}
+	c.limits.entries[lc] = cachedLimits{limits: result, fetchedAt: time.Now()}
+	return result, nil
+}
+
+// fetchFileLimits requests the limits of lc from the service.
+func (c *Client) fetchFileLimits(lc LimitsContext, opts []CallOption) (*GetFileLimitsResponse, error) {
+	q := url.Values{}
+	if folder := c.uploadFolder(lc.Folder); folder != "" {
+		q.Set("folder", folder)
+	}
+	if lc.Category != "" {
+		q.Set("category", lc.Category)
+	}
+	path := apiPathPrefix + "/files/limits"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var result GetFileLimitsResponse
+	if err := c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get file limits", opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
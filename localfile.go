@@ -0,0 +1,104 @@
+package storagesdk
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// openFileContext opens path for reading, giving up when ctx is done. os.Open
+// itself cannot be interrupted, so on a hung filesystem the open continues in
+// the background and the file is closed once it eventually returns.
+func openFileContext(ctx context.Context, path string) (*os.File, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ctx.Done() == nil {
+		return os.Open(path)
+	}
+	type result struct {
+		f   *os.File
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		f, err := os.Open(path)
+		ch <- result{f, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.f, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-ch; r.f != nil {
+				r.f.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// ctxReader fails reads once ctx is done. A read already blocked in the
+// underlying reader is not interrupted; the next one returns ctx.Err().
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// partFile is a uniquely named temporary file next to dest that replaces dest
+// only on commit. Defer discard right after creating it: until commit
+// succeeds, discard closes and removes the file, also when the caller returns
+// early or panics.
+type partFile struct {
+	*os.File
+	dest      string
+	closed    bool
+	committed bool
+}
+
+// createPartFile creates the temporary file for dest in dest's directory.
+func createPartFile(dest string) (*partFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.part")
+	if err != nil {
+		return nil, err
+	}
+	return &partFile{File: f, dest: dest}, nil
+}
+
+// close closes the file once; later calls return nil.
+func (p *partFile) close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	return p.File.Close()
+}
+
+// commit closes the file and renames it onto dest.
+func (p *partFile) commit() error {
+	if err := p.close(); err != nil {
+		return err
+	}
+	if err := os.Rename(p.Name(), p.dest); err != nil {
+		return err
+	}
+	p.committed = true
+	return nil
+}
+
+// discard removes the file unless it was committed.
+func (p *partFile) discard() {
+	if p.committed {
+		return
+	}
+	p.close()
+	os.Remove(p.Name())
+}
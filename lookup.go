@@ -0,0 +1,44 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// originalNameQuery builds a list query matching files by exact original name.
+func originalNameQuery(originalName string) url.Values {
+	return url.Values{"original_name_eq": {originalName}}
+}
+
+// GetFileByName returns the single file whose original name is originalName. It returns an
+// error wrapping ErrNotFound when no file matches and ErrMultipleMatches when several do (use
+// ListFilesByName to get them all).
+func (c *Client) GetFileByName(originalName string, opts ...CallOption) (*FileItem, error) {
+	if originalName == "" {
+		return nil, fmt.Errorf("original name is required")
+	}
+	q := originalNameQuery(originalName)
+	q.Set("per_page", "2")
+	resp, err := c.ListFiles(q.Encode(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(resp.Data) == 0:
+		return nil, fmt.Errorf("%w: no file named %q", ErrNotFound, originalName)
+	case len(resp.Data) > 1 || (resp.Pagination != nil && resp.Pagination.Total > 1):
+		return nil, fmt.Errorf("%w: several files named %q", ErrMultipleMatches, originalName)
+	}
+	return &resp.Data[0], nil
+}
+
+// ListFilesByName returns all files whose original name is originalName, across all pages.
+// opts apply to every page request; WithContext cancels the listing.
+func (c *Client) ListFilesByName(originalName string, opts ...CallOption) ([]FileItem, error) {
+	if originalName == "" {
+		return nil, fmt.Errorf("original name is required")
+	}
+	it := c.NewFileIterator(originalNameQuery(originalName).Encode(), IteratorOptions{Context: newCallOptions(opts).ctx})
+	it.callOpts = opts
+	return it.All()
+}
@@ -0,0 +1,67 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// metadataUpdateAttempts bounds read-modify-write retries after a concurrent modification.
+const metadataUpdateAttempts = 3
+
+// DeleteMetadataKeys removes the given keys from a file's metadata, leaving all other keys
+// untouched. It reads the current metadata, removes the keys and writes the map back; no
+// update is sent when none of the keys are present. The write is conditional only when the
+// service returns a strong ETag for the read: without one, a concurrent writer's changes made
+// between the read and the write are overwritten.
+func (c *Client) DeleteMetadataKeys(fileID string, keys []string, opts ...CallOption) (*GetFileResponse, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one metadata key is required")
+	}
+	return c.updateMetadata(fileID, func(metadata map[string]interface{}) bool {
+		changed := false
+		for _, k := range keys {
+			if _, ok := metadata[k]; ok {
+				delete(metadata, k)
+				changed = true
+			}
+		}
+		return changed
+	}, opts...)
+}
+
+// updateMetadata performs a read-modify-write of a file's metadata. mutate edits the map in
+// place and reports whether an update is needed. When the server returns a strong ETag for the
+// read, the write is sent with If-Match and retried from a fresh read on 412 Precondition
+// Failed, so concurrent writers do not clobber each other's keys. Without a strong ETag the
+// write is unconditional and may overwrite keys written concurrently.
+func (c *Client) updateMetadata(fileID string, mutate func(map[string]interface{}) bool, opts ...CallOption) (*GetFileResponse, error) {
+	for attempt := 1; ; attempt++ {
+		var header http.Header
+		current, err := c.GetFile(fileID, withOpts(opts, WithResponseHeader(&header))...)
+		if err != nil {
+			return nil, err
+		}
+		metadata := current.Data.Metadata
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		if !mutate(metadata) {
+			return current, nil
+		}
+
+		updateOpts := opts
+		if etag := header.Get("ETag"); etag != "" {
+			if _, weak := parseETag(etag); !weak {
+				updateOpts = withOpts(opts, WithHeader("If-Match", etag))
+			}
+		}
+		result, err := c.UpdateFile(fileID, UpdateFileRequest{Metadata: &metadata}, updateOpts...)
+		if apiErr, ok := IsAPIError(err); ok && apiErr.StatusCode == http.StatusPreconditionFailed && attempt < metadataUpdateAttempts {
+			continue
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,51 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// MoveFileRequest represents the request body for moving a file
+type MoveFileRequest struct {
+	Path string `json:"path"`
+}
+
+// MoveFile moves a file to newPath (a folder path such as "invoices/2024"), placed under
+// Config.PathPrefix when one is configured, and returns the updated file. A file with the same
+// name at the destination yields an error wrapping ErrAlreadyExists; a service without a move
+// endpoint yields one wrapping ErrNotSupported.
+func (c *Client) MoveFile(fileID, newPath string, opts ...CallOption) (*FileItem, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	cleaned, err := cleanFolderPath(newPath)
+	if err != nil {
+		return nil, err
+	}
+	reqPath := apiPathPrefix + "/files/" + pathSeg(fileID) + "/move"
+	var result GetFileResponse
+	err = c.do(http.MethodPost, reqPath, MoveFileRequest{Path: c.uploadFolder(cleaned)}, []int{http.StatusOK}, &result, "failed to move file", opts...)
+	if err != nil {
+		return nil, asAlreadyExists(asNotSupported(err))
+	}
+	return &result.Data, nil
+}
+
+// cleanFolderPath validates a relative folder path, rejecting empty paths and parent references.
+func cleanFolderPath(p string) (string, error) {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if seg == ".." {
+			return "", fmt.Errorf("invalid path %q: parent references are not allowed", p)
+		}
+	}
+	if strings.ContainsAny(p, "\\\x00") {
+		return "", fmt.Errorf("invalid path %q", p)
+	}
+	return path.Clean(p), nil
+}
@@ -0,0 +1,217 @@
+package storagesdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption customizes a single API call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	ctx         context.Context
+	headers     http.Header
+	respHeaders []*http.Header
+	streamBody  bool
+	encrypt     bool // encrypt uploaded file content with Config.Encryption
+	progress    ProgressFunc
+	statuses    []int // overrides the method's accepted success statuses
+	mpParams    map[string]string
+	pollMax     time.Duration // WaitUntilStatus backs off exponentially up to this interval
+	statusCode  *int          // receives the HTTP status code of the response
+	info        *CallInfo
+	verifyHash  bool // check downloaded content against FileItem.Hash
+}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	co := &callOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(co)
+		}
+	}
+	return co
+}
+
+// WithResponseHeader stores the headers of the call's HTTP response in dst
+// (e.g. X-Request-ID or rate-limit headers). dst is set for error responses too.
+func WithResponseHeader(dst *http.Header) CallOption {
+	return func(co *callOptions) {
+		if dst != nil {
+			co.respHeaders = append(co.respHeaders, dst)
+		}
+	}
+}
+
+// WithContext sets the context for the call; cancellation or deadline expiry
+// aborts the request. For uploads it also bounds opening and reading the local
+// files, which happens before the request is sent and is therefore not covered
+// by Config.Timeout. Opening gives up as soon as the context is done; a read
+// already blocked in the operating system cannot be interrupted, so the upload
+// fails when that read returns.
+func WithContext(ctx context.Context) CallOption {
+	return func(co *callOptions) {
+		co.ctx = ctx
+	}
+}
+
+// WithHeader sets an additional request header for the call.
+func WithHeader(key, value string) CallOption {
+	return func(co *callOptions) {
+		if co.headers == nil {
+			co.headers = make(http.Header)
+		}
+		co.headers.Set(key, value)
+	}
+}
+
+// WithAccept sets the Accept header for content negotiation, e.g. "image/webp"
+// on DownloadFile or ServeFileContent against a transcoding server. The
+// negotiated type is the response's Content-Type; a server that cannot satisfy
+// it returns an *APIError with status 406.
+func WithAccept(mediaType string) CallOption {
+	return WithHeader("Accept", mediaType)
+}
+
+// ProgressFunc reports transfer progress: bytes transferred so far and the total, or -1 when
+// the total is unknown. It is called from the goroutine performing the transfer.
+type ProgressFunc func(bytesDone, totalBytes int64)
+
+// WithProgress reports progress while content streams through DownloadToFile, DownloadTo or
+// GetFileBytes, and while multipart uploads send their request body (see
+// UploadOptions.Progress). Chunked uploads report the bytes confirmed after each chunk.
+func WithProgress(fn ProgressFunc) CallOption {
+	return func(co *callOptions) {
+		co.progress = fn
+	}
+}
+
+// WithHashVerification makes DownloadFile and the downloads built on it
+// (DownloadToFile, DownloadTo, GetFileBytes) check the content against the
+// hash the service recorded, see DownloadVerified.
+func WithHashVerification() CallOption {
+	return func(co *callOptions) {
+		co.verifyHash = true
+	}
+}
+
+// WithStreamingBody encodes the JSON request body into the request through a
+// pipe instead of marshaling it into a separate buffer first, which saves one
+// in-memory copy of very large bodies, such as UpdateFile with big metadata.
+// The request is then sent with chunked transfer encoding (no Content-Length)
+// and, as it cannot be replayed, is not retried. Small bodies are best left
+// buffered.
+//
+// The SDK cannot choose streaming by body size on its own: the size is only
+// known once the value has been encoded, and encoding/json encodes the whole
+// value before writing any of it, so by then the buffered copy already exists.
+func WithStreamingBody() CallOption {
+	return func(co *callOptions) {
+		co.streamBody = true
+	}
+}
+
+// WithSuccessStatuses replaces the HTTP statuses a call accepts as success, for
+// gateways that rewrite them (e.g. 200 instead of 201 on upload). It applies
+// to calls that decode a JSON response; include every status the call should
+// accept.
+func WithSuccessStatuses(codes ...int) CallOption {
+	return func(co *callOptions) {
+		co.statuses = append([]int(nil), codes...)
+	}
+}
+
+// WithMultipartParams adds parameters to the multipart/form-data Content-Type
+// header of uploads (e.g. {"charset": "utf-8"}) for backends that validate the
+// full header. The boundary parameter is always set by the SDK and cannot be
+// overridden. Calls without a multipart body ignore it.
+func WithMultipartParams(params map[string]string) CallOption {
+	return func(co *callOptions) {
+		if co.mpParams == nil {
+			co.mpParams = make(map[string]string, len(params))
+		}
+		for k, v := range params {
+			co.mpParams[k] = v
+		}
+	}
+}
+
+// WithPollBackoff makes WaitUntilStatus back off exponentially: the first
+// poll follows pollInterval, and each further interval doubles up to max. Short
+// processing is then noticed quickly while long processing is polled rarely.
+// A max not above pollInterval keeps the fixed interval.
+func WithPollBackoff(max time.Duration) CallOption {
+	return func(co *callOptions) {
+		co.pollMax = max
+	}
+}
+
+// withEncryption marks an upload whose file content is encrypted when Config.Encryption is set.
+func withEncryption() CallOption {
+	return func(co *callOptions) {
+		co.encrypt = true
+	}
+}
+
+// withStatusCode stores the HTTP status code of the call's response in dst.
+func withStatusCode(dst *int) CallOption {
+	return func(co *callOptions) {
+		co.statusCode = dst
+	}
+}
+
+// metadataOpts returns the options of a call that also apply to the internal
+// metadata lookups it makes (GetFile, GetFileLimits): only the context and a
+// per-call Authorization header. Content negotiation, extra headers, success
+// statuses and response capture are meant for the call's own request.
+func metadataOpts(opts []CallOption) []CallOption {
+	co := newCallOptions(opts)
+	var out []CallOption
+	if co.ctx != nil {
+		out = append(out, WithContext(co.ctx))
+	}
+	if auth := co.headers.Get("Authorization"); auth != "" {
+		out = append(out, WithHeader("Authorization", auth))
+	}
+	return out
+}
+
+// withOpts returns opts extended with more, without aliasing the caller's slice.
+func withOpts(opts []CallOption, more ...CallOption) []CallOption {
+	out := make([]CallOption, 0, len(opts)+len(more))
+	out = append(out, opts...)
+	return append(out, more...)
+}
+
+func (co *callOptions) context() context.Context {
+	if co.ctx != nil {
+		return co.ctx
+	}
+	return context.Background()
+}
+
+// successStatuses returns the statuses set with WithSuccessStatuses, or def.
+func (co *callOptions) successStatuses(def []int) []int {
+	if len(co.statuses) > 0 {
+		return co.statuses
+	}
+	return def
+}
+
+// applyRequest applies request-related call options.
+func (co *callOptions) applyRequest(req *http.Request) {
+	for k, v := range co.headers {
+		req.Header[k] = append([]string(nil), v...)
+	}
+}
+
+// captureResponse applies response-related call options.
+func (co *callOptions) captureResponse(resp *http.Response) {
+	for _, dst := range co.respHeaders {
+		*dst = resp.Header.Clone()
+	}
+	if co.statusCode != nil {
+		*co.statusCode = resp.StatusCode
+	}
+}
@@ -0,0 +1,126 @@
+package storagesdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// FileUpload is one upload fed to UploadStream: the content of Reader, or of
+// the local file at Path when Reader is nil.
+type FileUpload struct {
+	Name     string    // stored file name; defaults to the base name of Path
+	Reader   io.Reader // content to upload; takes precedence over Path
+	Path     string    // local file to upload when Reader is nil
+	Metadata string    // optional JSON object string (see UploadOptions.Metadata)
+}
+
+// UploadResult is the outcome of one FileUpload read by UploadStream, or of
+// one file uploaded by UploadFilesConcurrent.
+type UploadResult struct {
+	Upload FileUpload // the upload as read from the input channel
+	File   *FileItem  // the stored file (nil on error)
+	Err    error      // non-nil if this upload failed
+}
+
+// UploadStream uploads every FileUpload received on in, one request per file,
+// with at most concurrency uploads in flight (defaultBulkConcurrency when <= 0),
+// and emits one UploadResult per upload on the returned channel, in completion
+// order. The channel is closed once in is closed (or ctx is done) and all
+// started uploads have finished. Cancelling ctx stops reading from in and
+// aborts uploads in flight, whose results report the context error. The caller
+// must drain the returned channel.
+func (c *Client) UploadStream(ctx context.Context, in <-chan FileUpload, concurrency int, opts ...CallOption) <-chan UploadResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	opts = withOpts(opts, WithContext(ctx))
+	out := make(chan UploadResult)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var u FileUpload
+				var ok bool
+				select {
+				case <-ctx.Done():
+					return
+				case u, ok = <-in:
+					if !ok {
+						return
+					}
+				}
+				file, err := c.uploadOne(u, opts)
+				out <- UploadResult{Upload: u, File: file, Err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// UploadFilesConcurrent uploads local files one request per file, with at
+// most concurrency uploads in flight (defaultBulkConcurrency when <= 0), and
+// returns one UploadResult per path in input order. metadataJSON is an
+// optional JSON object string applied to every file. A failed file is
+// reported in its result's Err without aborting the others, so partial
+// successes are kept.
+func (c *Client) UploadFilesConcurrent(filePaths []string, concurrency int, metadataJSON string, opts ...CallOption) ([]UploadResult, error) {
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("at least one file path is required")
+	}
+	results := make([]UploadResult, len(filePaths))
+	forEachConcurrent(len(filePaths), concurrency, func(i int) {
+		u := FileUpload{Path: filePaths[i], Metadata: metadataJSON}
+		file, err := c.uploadOne(u, opts)
+		results[i] = UploadResult{Upload: u, File: file, Err: err}
+	})
+	return results, nil
+}
+
+// uploadOne uploads a single FileUpload.
+func (c *Client) uploadOne(u FileUpload, opts []CallOption) (*FileItem, error) {
+	f := formFile{field: defaultUploadField, name: u.Name, reader: u.Reader}
+	if u.Reader == nil {
+		if u.Path == "" {
+			return nil, fmt.Errorf("failed to upload file: reader or path is required")
+		}
+		if err := validateFilePaths([]string{u.Path}); err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		if err := c.checkUploadPolicy([]string{u.Path}); err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		f.path = u.Path
+		if f.name == "" {
+			_, f.name = splitPath(u.Path)
+		}
+	} else if f.name == "" {
+		return nil, fmt.Errorf("failed to upload file: file name is required")
+	}
+	uploaded, err := c.upload([]formFile{f}, UploadOptions{Metadata: u.Metadata}, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	return firstUploaded(f.name, uploaded)
+}
+
+// firstUploaded returns the single file stored by an upload of name.
+func firstUploaded(name string, uploaded *UploadFileResponse) (*FileItem, error) {
+	if len(uploaded.Data.UploadedFiles) == 0 {
+		if len(uploaded.Data.FailedUploads) > 0 {
+			return nil, fmt.Errorf("failed to upload files: %s rejected: %v", name, uploaded.Data.FailedUploads[0])
+		}
+		return nil, fmt.Errorf("failed to upload files: no file in response")
+	}
+	return &uploaded.Data.UploadedFiles[0], nil
+}
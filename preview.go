@@ -0,0 +1,102 @@
+package storagesdk
+
+import (
+	"fmt"
+	"mime/multipart"
+	"os"
+)
+
+// UploadPreview describes the multipart request UploadFile would send.
+type UploadPreview struct {
+	// ContentLength is the exact request body size in bytes, or -1 when it
+	// cannot be known in advance (client-side encryption is configured).
+	ContentLength int64
+	// ContentType is the multipart Content-Type header including the boundary.
+	// A fresh random boundary is generated for every request, but it always has
+	// the same length, so ContentLength does not depend on it.
+	ContentType string
+	Boundary    string
+	TotalSize   int64 // sum of the file sizes
+	Files       []PreviewFile
+	// FitsLimits is true when every file is within its size limit.
+	FitsLimits bool
+}
+
+// PreviewFile is a single file of an UploadPreview.
+type PreviewFile struct {
+	Path    string
+	Name    string // file name sent in the form part
+	Size    int64
+	MaxSize int64 // size limit for this file (0 means unlimited)
+	Fits    bool
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct{ n int64 }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// PreviewUpload reports what UploadFile(filePaths, metadataJSON) would send,
+// without sending it: the body size and boundary, per-file sizes and whether
+// each file fits under the service's size limits (fetched via GetFileLimits).
+func (c *Client) PreviewUpload(filePaths []string, metadataJSON string, opts ...CallOption) (*UploadPreview, error) {
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("at least one file path is required")
+	}
+	if err := validateFilePaths(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to preview upload: %w", err)
+	}
+	formValues, _, err := c.prepareUpload(UploadOptions{Metadata: metadataJSON}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := c.GetFileLimits(metadataOpts(opts)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview upload: get file limits: %w", err)
+	}
+
+	counter := &countingWriter{}
+	w := multipart.NewWriter(counter)
+	preview := &UploadPreview{
+		ContentType: w.FormDataContentType(),
+		Boundary:    w.Boundary(),
+		Files:       make([]PreviewFile, 0, len(filePaths)),
+		FitsLimits:  true,
+	}
+	for _, p := range filePaths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview upload: %w", err)
+		}
+		_, name := splitPath(p)
+		if _, err := w.CreateFormFile("files", name); err != nil {
+			return nil, fmt.Errorf("failed to preview upload: create form file: %w", err)
+		}
+		counter.n += info.Size()
+		f := PreviewFile{Path: p, Name: name, Size: info.Size(), MaxSize: limits.maxSizeFor(name)}
+		f.Fits = f.MaxSize <= 0 || f.Size <= f.MaxSize
+		if !f.Fits {
+			preview.FitsLimits = false
+		}
+		preview.TotalSize += f.Size
+		preview.Files = append(preview.Files, f)
+	}
+	for k, values := range formValues {
+		for _, v := range values {
+			if err := w.WriteField(k, v); err != nil {
+				return nil, fmt.Errorf("failed to preview upload: write field: %w", err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to preview upload: close multipart: %w", err)
+	}
+	preview.ContentLength = counter.n
+	if c.encryption != nil {
+		preview.ContentLength = -1
+	}
+	return preview, nil
+}
@@ -0,0 +1,149 @@
+package storagesdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListFilesInto returns every file matching queryString across all pages (like
+// ListAllFiles), mapped through fn into a caller-defined type. Items are decoded
+// from the response one at a time and passed to fn immediately, so only the
+// projected values are retained, not whole pages of FileItem.
+//
+//	type row struct{ Name string; Size int64 }
+//	rows, err := storagesdk.ListFilesInto(client, "status_eq=active", func(f storagesdk.FileItem) row {
+//		return row{f.OriginalName, f.FileSize}
+//	})
+func ListFilesInto[T any](c *Client, queryString string, fn func(FileItem) T, opts ...CallOption) ([]T, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("mapping function is required")
+	}
+	query, err := url.ParseQuery(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query string: %w", err)
+	}
+	page := 0
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	var out []T
+	for {
+		if page > 0 {
+			query.Set("page", strconv.Itoa(page))
+		}
+		pagination, err := c.streamListPage(query.Encode(), func(item FileItem) {
+			out = append(out, fn(item))
+		}, opts)
+		if err != nil {
+			return nil, err
+		}
+		page = nextPageNumber(pagination, page)
+		if page == 0 {
+			return out, nil
+		}
+	}
+}
+
+// streamListPage fetches one listing page and calls yield for each item as it
+// is decoded, returning the page's pagination metadata.
+func (c *Client) streamListPage(queryString string, yield func(FileItem), opts []CallOption) (*Pagination, error) {
+	path := apiPathPrefix + "/files"
+	if queryString = c.scopeQuery(queryString); queryString != "" {
+		path += "?" + queryString
+	}
+	resp, err := c.doRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if err := redirectError(resp); err != nil {
+			return nil, err
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.apiError(resp.StatusCode, body)
+	}
+	pagination, err := decodeListStream(resp.Body, c.unmarshalResponse, yield)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	return pagination, nil
+}
+
+// decodeListStream decodes a ListFilesResponse body token by token, calling
+// yield for each element of "data" instead of collecting them. Each item and
+// the pagination are decoded with unmarshal.
+func decodeListStream(r io.Reader, unmarshal func([]byte, interface{}) error, yield func(FileItem)) (*Pagination, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	var pagination *Pagination
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok {
+		case "data":
+			if err := decodeItems(dec, unmarshal, yield); err != nil {
+				return nil, err
+			}
+		case "pagination":
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			if err := unmarshal(raw, &pagination); err != nil {
+				return nil, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return pagination, expectDelim(dec, '}')
+}
+
+// decodeItems decodes a JSON array (or null) of FileItem, one element at a time.
+func decodeItems(dec *json.Decoder, unmarshal func([]byte, interface{}) error, yield func(FileItem)) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("unexpected %v for data, want array", tok)
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		var item FileItem
+		if err := unmarshal(raw, &item); err != nil {
+			return err
+		}
+		yield(item)
+	}
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("unexpected %v, want %v", tok, want)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package storagesdk
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// proxiedRequestHeaders are the conditional request headers ProxyContent
+// forwards to the service.
+var proxiedRequestHeaders = []string{"If-None-Match", "If-Modified-Since"}
+
+// proxiedResponseHeaders are the response headers ProxyContent copies to the
+// client.
+var proxiedResponseHeaders = []string{"Content-Type", "Content-Length", "ETag", "Last-Modified", "Cache-Control"}
+
+// ProxyContent serves a file's content (see ServeFileContent) on w in answer to
+// r, so an application can put its own authorization in front of storage. It
+// forwards r's conditional headers (If-None-Match, If-Modified-Since), copies
+// Content-Type, Content-Length, ETag, Last-Modified and Cache-Control, and
+// streams the body (headers only for HEAD requests; a 304 Not Modified is passed
+// through). The request is bound to r's context. If the content cannot be
+// fetched, ProxyContent answers 404 for missing files and 502 Bad Gateway
+// otherwise; the returned error, also for failures while streaming, is meant
+// for logging.
+func (c *Client) ProxyContent(w http.ResponseWriter, r *http.Request, fileID string, opts ...CallOption) error {
+	callOpts := []CallOption{WithContext(r.Context())}
+	for _, h := range proxiedRequestHeaders {
+		if v := r.Header.Get(h); v != "" {
+			callOpts = append(callOpts, WithHeader(h, v))
+		}
+	}
+	resp, err := c.ServeFileContent(fileID, append(callOpts, opts...)...)
+	if err != nil {
+		status := http.StatusBadGateway
+		if apiErr, ok := IsAPIError(err); ok && apiErr.StatusCode == http.StatusNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, http.StatusText(status), status)
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, h := range proxiedResponseHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			w.Header().Set(h, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	if r.Method == http.MethodHead || resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to proxy file content: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package storagesdk
+
+import "net/url"
+
+// BuildQuery builds a properly escaped query string from filter and pagination parameters,
+// for ListFiles and the other helpers taking a queryString. Values containing spaces,
+// ampersands or non-ASCII characters are escaped; keys are sorted for stable output.
+//
+//	q := storagesdk.BuildQuery(map[string]string{"original_name_eq": "Q&A notes.pdf", "per_page": "50"})
+//	resp, err := client.ListFiles(q)
+func BuildQuery(params map[string]string) string {
+	values := make(url.Values, len(params))
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
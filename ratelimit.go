@@ -0,0 +1,93 @@
+package storagesdk
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate-limit response headers read by the client.
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimit is the rate-limit state last reported by the service.
+type RateLimit struct {
+	Limit     int       // Requests allowed in the current window (-1 if not reported)
+	Remaining int       // Requests left in the current window
+	Reset     time.Time // When the window resets (zero if not reported)
+	UpdatedAt time.Time // When the headers were received
+}
+
+// rateLimitState tracks the latest RateLimit seen on any response.
+type rateLimitState struct {
+	mu    sync.Mutex
+	last  RateLimit
+	valid bool
+}
+
+// RateLimitStatus returns the rate-limit values from the most recent response
+// that carried X-RateLimit-Remaining, and false if none has been seen yet.
+// Use it to throttle proactively before the service answers 429.
+func (c *Client) RateLimitStatus() (RateLimit, bool) {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	return c.rateLimit.last, c.rateLimit.valid
+}
+
+// recordRateLimit stores the rate-limit headers of resp, if present.
+func (c *Client) recordRateLimit(resp *http.Response) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get(headerRateLimitRemaining)))
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	rl := RateLimit{Limit: -1, Remaining: remaining, UpdatedAt: now}
+	if limit, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get(headerRateLimitLimit))); err == nil {
+		rl.Limit = limit
+	}
+	rl.Reset = parseRateLimitReset(resp.Header.Get(headerRateLimitReset), now)
+
+	c.rateLimit.mu.Lock()
+	c.rateLimit.last, c.rateLimit.valid = rl, true
+	c.rateLimit.mu.Unlock()
+}
+
+// parseRateLimitReset accepts both conventions for X-RateLimit-Reset: seconds
+// until the reset, or the reset time as Unix seconds.
+func parseRateLimitReset(value string, now time.Time) time.Time {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}
+	}
+	if n > 1_000_000_000 {
+		return time.Unix(n, 0)
+	}
+	return now.Add(time.Duration(n) * time.Second)
+}
+
+// waitForRateLimit blocks until the reported window resets when the last
+// response left no requests, or until ctx is done.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	rl, ok := c.RateLimitStatus()
+	if !ok || rl.Remaining > 0 || rl.Reset.IsZero() {
+		return nil
+	}
+	wait := time.Until(rl.Reset)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+package storagesdk
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+)
+
+// BuildUploadRequest returns the request UploadFile(filePaths, metadataJSON)
+// would send, without sending it, so tests can assert its headers and body and
+// callers can adjust it before sending it with DoRaw. The body is held in
+// memory (and can be re-read through GetBody), so prefer UploadFile for large
+// files. Request signing happens when the request is sent.
+func (c *Client) BuildUploadRequest(filePaths []string, metadataJSON string, opts ...CallOption) (*http.Request, error) {
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("at least one file path is required")
+	}
+	if err := validateFilePaths(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to build upload request: %w", err)
+	}
+	if err := c.checkUploadPolicy(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to build upload request: %w", err)
+	}
+	formValues, opts, err := c.prepareUpload(UploadOptions{Metadata: metadataJSON}, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	co := newCallOptions(opts)
+	var body bytes.Buffer
+	contentType, err := c.writeMultipart(&body, pathFormFiles(defaultUploadField, filePaths), formValues, co)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upload request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(co.context(), http.MethodPost, c.baseURL+apiPathPrefix+"/files/", bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	co.applyRequest(req)
+	return req, nil
+}
+
+// DoRaw sends req through the client, as its API methods do: it counts
+// towards Shutdown, honours Config.PauseOnRateLimit, is signed by
+// Config.Signer and applies call options such as WithHeader and
+// WithResponseHeader. The response is returned whatever its status; the caller
+// must close its body.
+func (c *Client) DoRaw(req *http.Request, opts ...CallOption) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is required")
+	}
+	return c.send(req, newCallOptions(opts))
+}
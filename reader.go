@@ -0,0 +1,153 @@
+package storagesdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// maxConcurrentRangeReads bounds the ranged GETs a single FileReader issues concurrently
+// through ReadAt.
+const maxConcurrentRangeReads = 4
+
+// FileReader gives random access to stored content using HTTP Range requests. It implements
+// io.ReadSeeker, io.ReaderAt and io.Closer, so libraries expecting a seekable source (media
+// probes, PDF renderers, zip readers) can work against stored files without downloading them.
+//
+// Read streams from the current offset over a single ranged GET; Seek only moves the offset
+// and the next Read issues a new request, so every seek-then-read costs one round trip.
+// ReadAt issues one ranged GET per call, at most maxConcurrentRangeReads at a time.
+type FileReader struct {
+	c      *Client
+	fileID string
+	size   int64
+	opts   []CallOption
+	sem    chan struct{}
+
+	mu     sync.Mutex // guards offset and body
+	offset int64
+	body   io.ReadCloser
+}
+
+// OpenFile returns a FileReader for a stored file. The file size is taken from its metadata.
+// Caller must Close the reader.
+func (c *Client) OpenFile(fileID string, opts ...CallOption) (*FileReader, error) {
+	file, err := c.GetFile(fileID, metadataOpts(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return &FileReader{
+		c:      c,
+		fileID: fileID,
+		size:   file.Data.FileSize,
+		opts:   opts,
+		sem:    make(chan struct{}, maxConcurrentRangeReads),
+	}, nil
+}
+
+// Size returns the content length of the file.
+func (r *FileReader) Size() int64 {
+	return r.size
+}
+
+// Read implements io.Reader.
+func (r *FileReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		resp, err := r.c.getRange(r.fileID, r.offset, -1, r.opts...)
+		if err != nil {
+			return 0, err
+		}
+		r.body = resp.Body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err == io.EOF {
+		r.body.Close()
+		r.body = nil
+		if r.offset < r.size {
+			return n, io.ErrUnexpectedEOF
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker. It does not perform I/O.
+func (r *FileReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("seek: negative position")
+	}
+	if abs != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = abs
+	return abs, nil
+}
+
+// ReadAt implements io.ReaderAt. It is safe for concurrent use.
+func (r *FileReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("read at: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	want := p
+	if remaining := r.size - off; int64(len(p)) > remaining {
+		want = p[:remaining]
+	}
+
+	r.sem <- struct{}{}
+	defer func() { <-r.sem }()
+
+	resp, err := r.c.getRange(r.fileID, off, off+int64(len(want))-1, r.opts...)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	n, err := io.ReadFull(resp.Body, want)
+	if err != nil {
+		return n, fmt.Errorf("read range: %w", err)
+	}
+	if len(want) < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Close releases the open range request, if any.
+func (r *FileReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.body != nil {
+		err := r.body.Close()
+		r.body = nil
+		return err
+	}
+	return nil
+}
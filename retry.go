@@ -0,0 +1,236 @@
+package storagesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backoff of the client's own retries (Config.MaxRetries).
+const (
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 30 * time.Second
+)
+
+// permanentError marks an error that Retry must not retry.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that Retry returns it immediately instead of trying
+// again. Retry returns the original err, not the wrapper.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsRetryable reports whether err looks transient: an *APIError whose
+// IsRetryable is true, a network error, or an incomplete download. Context
+// cancellation and deadline errors are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if apiErr, ok := IsAPIError(err); ok {
+		return apiErr.IsRetryable()
+	}
+	if errors.Is(err, ErrIncompleteDownload) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Retry calls op up to attempts times until it returns nil. Before retry n
+// (1-based) it waits backoff(n); a nil backoff retries immediately. op decides
+// what is worth retrying: return Permanent(err) to stop, e.g.
+//
+//	err := storagesdk.Retry(ctx, 5, backoff, func() error {
+//		_, err := client.UploadFile(paths, "")
+//		if err != nil && !storagesdk.IsRetryable(err) {
+//			return storagesdk.Permanent(err)
+//		}
+//		return err
+//	})
+//
+// Retry stops early when ctx is done, returning the last error from op (or
+// ctx.Err() if op was never called).
+func Retry(ctx context.Context, attempts int, backoff func(int) time.Duration, op func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for n := 0; n < attempts; n++ {
+		if n > 0 && backoff != nil {
+			if d := backoff(n); d > 0 {
+				timer := time.NewTimer(d)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return ctxErr
+			}
+			return err
+		}
+		err = op()
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+	}
+	return err
+}
+
+// retryBackoff returns the wait before retry n (1-based): RetryBaseDelay,
+// doubled for every further retry, at most maxRetryDelay.
+func (c *Client) retryBackoff(n int) time.Duration {
+	d := c.retryBaseDelay
+	for i := 1; i < n && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+	return min(d, maxRetryDelay)
+}
+
+// idempotentMethods are the request methods send retries (Config.MaxRetries).
+var idempotentMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPut: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+// maxRetryBody caps the error body kept in memory while waiting to retry.
+const maxRetryBody = 64 << 10
+
+// send dispatches req through sendOnce, retrying idempotent requests (GET,
+// HEAD, PUT, DELETE and OPTIONS, see idempotentMethods) with a replayable body
+// up to Config.MaxRetries times on network errors and on 408, 425, 429, 500,
+// 502, 503 and 504. The wait is
+// retryBackoff or the response's Retry-After, whichever is longer; retrying
+// stops when the wait would pass the context's deadline or Retry-After asks
+// for more than maxRetryDelay, returning the last response or error.
+func (c *Client) send(req *http.Request, co *callOptions) (*http.Response, error) {
+	if c.maxRetries == 0 || !idempotentMethods[req.Method] || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return c.sendOnce(req, co)
+	}
+	ctx := req.Context()
+	for n := 0; ; n++ {
+		attempt := req.Clone(ctx)
+		if n > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+		resp, err := c.sendOnce(attempt, co)
+		if n == c.maxRetries {
+			return resp, err
+		}
+		wait := c.retryBackoff(n + 1)
+		switch {
+		case err != nil:
+			if !IsRetryable(err) {
+				return nil, err
+			}
+		case (&APIError{StatusCode: resp.StatusCode}).IsRetryable():
+			if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if after > maxRetryDelay {
+					return resp, nil
+				}
+				wait = max(wait, after)
+			}
+		default:
+			return resp, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+		if resp != nil {
+			// Keep the error body so the response can still be returned if
+			// the context ends while waiting.
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRetryBody))
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date, reporting false when it is absent or invalid.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// retryUpload runs op, an upload of files, retrying transient failures as
+// configured by Config.RetryUploads and MaxRetries. Uploads of readers, which
+// cannot be replayed, run once.
+func (c *Client) retryUpload(files []formFile, callOpts []CallOption, op func() error) error {
+	if !c.retryUploads || c.maxRetries == 0 || !replayable(files) {
+		return op()
+	}
+	return Retry(newCallOptions(callOpts).context(), c.maxRetries+1, c.retryBackoff, func() error {
+		err := op()
+		if err != nil && !isRetryableUpload(err) {
+			return Permanent(err)
+		}
+		return err
+	})
+}
+
+// isRetryableUpload reports whether a failed upload may succeed when sent
+// again. A "retryable" flag in the error body decides first; validation and
+// size errors are permanent; otherwise IsRetryable applies.
+func isRetryableUpload(err error) bool {
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		return IsRetryable(err)
+	}
+	var flag struct {
+		Retryable *bool `json:"retryable"`
+	}
+	if json.Unmarshal([]byte(apiErr.Body), &flag) == nil && flag.Retryable != nil {
+		return *flag.Retryable
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return false
+	}
+	return apiErr.IsRetryable()
+}
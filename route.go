@@ -0,0 +1,58 @@
+package storagesdk
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultUploadField is the multipart field files are sent in.
+const defaultUploadField = "files"
+
+// detectMIMEType returns the MIME type of a local file from its extension,
+// falling back to sniffing the first 512 bytes of content.
+func detectMIMEType(path string) (string, error) {
+	if t := mime.TypeByExtension(filepath.Ext(path)); t != "" {
+		return t, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// fieldForMIME returns the field of the longest MIME prefix in routes matching
+// mimeType, or defaultUploadField.
+func fieldForMIME(mimeType string, routes map[string]string) string {
+	mimeType = strings.ToLower(mimeType)
+	field, best := defaultUploadField, -1
+	for prefix, f := range routes {
+		p := strings.ToLower(prefix)
+		if strings.HasPrefix(mimeType, p) && len(p) > best {
+			field, best = f, len(p)
+		}
+	}
+	return field
+}
+
+// routeByMIME sets each file's form field from its detected MIME type.
+func routeByMIME(files []formFile, routes map[string]string) error {
+	for i := range files {
+		mimeType, err := detectMIMEType(files[i].path)
+		if err != nil {
+			return err
+		}
+		files[i].field = fieldForMIME(mimeType, routes)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package storagesdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Query parameters of full-text search.
+const (
+	searchParam    = "search"
+	highlightParam = "highlight"
+)
+
+// Highlights maps a matched field ("originalName", "metadata.title", ...) to
+// the highlighted fragments of its value, as marked up by the service.
+type Highlights map[string][]string
+
+// UnmarshalJSON accepts both a list of fragments and a single fragment per field.
+func (h *Highlights) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*h = nil
+		return nil
+	}
+	out := make(Highlights, len(raw))
+	for field, v := range raw {
+		var list []string
+		if err := json.Unmarshal(v, &list); err != nil {
+			var single string
+			if err := json.Unmarshal(v, &single); err != nil {
+				return fmt.Errorf("highlights for %q: %w", field, err)
+			}
+			list = []string{single}
+		}
+		out[field] = list
+	}
+	*h = out
+	return nil
+}
+
+// SearchResult is a file matching a search, with the fragments that matched.
+// Highlights is nil when the service returned no highlight data.
+type SearchResult struct {
+	FileItem
+	Highlights Highlights `json:"highlights,omitempty"`
+}
+
+// SearchFilesResponse represents the paginated response from SearchFiles
+type SearchFilesResponse struct {
+	Success    bool           `json:"success"`
+	Message    string         `json:"message"`
+	Status     int            `json:"status"`
+	Data       []SearchResult `json:"data"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+// SearchOptions configures SearchFiles.
+type SearchOptions struct {
+	// Query adds filters and pagination in ListFiles syntax (optional).
+	Query string
+
+	// Highlights asks the service to return the matching fragments of each
+	// result (sent as highlight=true).
+	Highlights bool
+}
+
+// SearchFiles runs a full-text search over file names and metadata (sent as
+// search=<term>), returning results with match highlights when requested and
+// provided by the service.
+func (c *Client) SearchFiles(term string, opts SearchOptions, callOpts ...CallOption) (*SearchFilesResponse, error) {
+	if term == "" {
+		return nil, fmt.Errorf("search term is required")
+	}
+	q, err := url.ParseQuery(opts.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query string: %w", err)
+	}
+	q.Set(searchParam, term)
+	if opts.Highlights {
+		q.Set(highlightParam, "true")
+	}
+	path := apiPathPrefix + "/files?" + c.scopeQuery(q.Encode())
+	var result SearchFilesResponse
+	err = c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to search files", callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
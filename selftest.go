@@ -0,0 +1,63 @@
+package storagesdk
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// selfTestMetadataKey marks files created by SelfTest.
+const selfTestMetadataKey = "storagesdkSelfTest"
+
+// SelfTest checks that the client is correctly configured against a deployment
+// by performing a small round trip: it uploads a temporary test file of a few
+// bytes (named "storagesdk-selftest-<timestamp>.txt", metadata
+// "storagesdkSelfTest": true), downloads it, verifies its content and hash, and
+// deletes it again. The returned error names the step that failed ("upload",
+// "download", "verify" or "delete"). The test file is deleted even if
+// downloading or verifying fails; only a failing delete can leave it behind.
+func (c *Client) SelfTest(opts ...CallOption) (err error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("self-test: %w", err)
+	}
+	content := []byte("storage-service-sdk-go self-test " + hex.EncodeToString(nonce) + "\n")
+	name := fmt.Sprintf("storagesdk-selftest-%d.txt", time.Now().UnixNano())
+
+	files := []formFile{{field: "files", name: name, reader: bytes.NewReader(content)}}
+	metadata := fmt.Sprintf(`{%q:true}`, selfTestMetadataKey)
+	uploaded, err := c.upload(files, UploadOptions{Metadata: metadata}, nil, opts)
+	if err != nil {
+		return fmt.Errorf("self-test: upload: %w", err)
+	}
+	if len(uploaded.Data.UploadedFiles) == 0 {
+		return fmt.Errorf("self-test: upload: no file in response")
+	}
+	file := uploaded.Data.UploadedFiles[0]
+	defer func() {
+		if delErr := c.DeleteFile(file.ID, opts...); delErr != nil {
+			err = errors.Join(err, fmt.Errorf("self-test: delete %s: %w", file.ID, delErr))
+		}
+	}()
+
+	got, err := c.GetFileBytes(file.ID, opts...)
+	if err != nil {
+		return fmt.Errorf("self-test: download: %w", err)
+	}
+	if !bytes.Equal(got, content) {
+		return fmt.Errorf("self-test: verify: downloaded content differs from upload (%d bytes, want %d)", len(got), len(content))
+	}
+	// With client-side encryption the service hashes the ciphertext.
+	if c.encryption == nil {
+		sum := sha256.Sum256(content)
+		expected := hex.EncodeToString(sum[:])
+		if hashesComparable(expected, file.Hash) && !hashEqual(expected, file.Hash) {
+			return fmt.Errorf("self-test: verify: %w", &HashMismatchError{FileID: file.ID, Name: name, Expected: expected, Actual: file.Hash, Algorithm: "sha256"})
+		}
+	}
+	return nil
+}
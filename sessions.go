@@ -0,0 +1,98 @@
+package storagesdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// SessionStore persists chunked upload sessions so UploadFileChunked can resume
+// an upload after the process restarts. Implementations must be safe for
+// concurrent use.
+type SessionStore interface {
+	// Save stores the session under key, replacing any previous state.
+	Save(key string, session ChunkedUpload) error
+	// Load returns the session stored under key, or nil and no error if there is none.
+	Load(key string) (*ChunkedUpload, error)
+	// Delete removes the session stored under key; a missing key is not an error.
+	Delete(key string) error
+}
+
+// uploadSessionKey identifies a chunked upload of a local file: the same path,
+// size and modification time map to the same key.
+func uploadSessionKey(filePath string, info os.FileInfo) string {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		abs = filePath
+	}
+	sum := sha256.Sum256([]byte(abs + "\x00" + strconv.FormatInt(info.Size(), 10) + "\x00" + strconv.FormatInt(info.ModTime().UnixNano(), 10)))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileSessionStore is a SessionStore keeping one JSON file per session.
+type fileSessionStore struct {
+	dir string
+}
+
+// NewFileSessionStore returns a SessionStore that keeps each session as a JSON
+// file in dir, which is created if needed. Writes are atomic (temp file and
+// rename), so a crash never leaves a half-written session behind.
+func NewFileSessionStore(dir string) (SessionStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("session directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create session directory: %w", err)
+	}
+	return &fileSessionStore{dir: dir}, nil
+}
+
+// path maps a key to a file name that is safe regardless of the key's content.
+func (s *fileSessionStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func (s *fileSessionStore) Save(key string, session ChunkedUpload) error {
+	raw, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	tmp, err := createPartFile(s.path(key))
+	if err != nil {
+		return err
+	}
+	defer tmp.discard()
+	if _, err := tmp.Write(raw); err != nil {
+		return err
+	}
+	return tmp.commit()
+}
+
+func (s *fileSessionStore) Load(key string) (*ChunkedUpload, error) {
+	raw, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session ChunkedUpload
+	if err := json.Unmarshal(raw, &session); err != nil {
+		return nil, fmt.Errorf("decode session: %w", err)
+	}
+	return &session, nil
+}
+
+func (s *fileSessionStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ShareLinkResponse represents the API response for a file's public link.
+type ShareLinkResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+	Data    struct {
+		URL string `json:"url"`
+	} `json:"data"`
+}
+
+// SetFilePublicRequest is the request body for SetFilePublic.
+type SetFilePublicRequest struct {
+	Public bool `json:"public"`
+}
+
+// GetShareLink returns the stable public URL of a file (GET
+// /files/:id/share), which anyone can open without credentials, unlike
+// FileContentURL. Files that are not public fail with an error wrapping
+// ErrNotShareable; make them public with SetFilePublic. It returns an error
+// wrapping ErrNotSupported if the service has no public links.
+func (c *Client) GetShareLink(fileID string, opts ...CallOption) (string, error) {
+	if fileID == "" {
+		return "", fmt.Errorf("file ID is required")
+	}
+	var result ShareLinkResponse
+	err := c.do(http.MethodGet, apiPathPrefix+"/files/"+pathSeg(fileID)+"/share", nil, []int{http.StatusOK}, &result, "failed to get share link", opts...)
+	if err != nil {
+		return "", asNotSupported(asNotShareable(err))
+	}
+	if result.Data.URL == "" {
+		return "", fmt.Errorf("failed to get share link: %w", ErrNotShareable)
+	}
+	return result.Data.URL, nil
+}
+
+// SetFilePublic makes a file publicly shareable or private again (PUT
+// /files/:id/visibility) and returns the updated file. Making a file private
+// invalidates its share link. It returns an error wrapping ErrNotSupported if
+// the service has no public links.
+func (c *Client) SetFilePublic(fileID string, public bool, opts ...CallOption) (*GetFileResponse, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	var result GetFileResponse
+	err := c.do(http.MethodPut, apiPathPrefix+"/files/"+pathSeg(fileID)+"/visibility", SetFilePublicRequest{Public: public}, []int{http.StatusOK}, &result, "failed to set file visibility", opts...)
+	if err != nil {
+		return nil, asNotSupported(err)
+	}
+	return &result, nil
+}
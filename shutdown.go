@@ -0,0 +1,81 @@
+package storagesdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrClientShutdown is returned for requests started after Shutdown was called.
+var ErrClientShutdown = errors.New("client is shut down")
+
+// lifecycle tracks in-flight requests so Shutdown can cancel and await them.
+type lifecycle struct {
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+	ctx    context.Context // canceled by Shutdown
+	cancel context.CancelFunc
+}
+
+// Shutdown cancels all in-flight requests, rejects new ones with
+// ErrClientShutdown and waits until the canceled operations have finished, or
+// until ctx is done (returning ctx.Err()). A request counts as in flight until
+// its response body is closed, so bodies returned to the caller (DownloadFile,
+// ServeFileContent) must be closed for Shutdown to complete. Unlike Close, the
+// client is unusable afterwards.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.life.mu.Lock()
+	c.life.closed = true
+	c.life.mu.Unlock()
+	c.life.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.life.wg.Wait()
+		close(done)
+	}()
+	defer c.httpClient.CloseIdleConnections()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// track registers req as in flight and binds it to the client's lifetime. The
+// returned done func must be called once the request and its response body
+// are finished with; it is safe to call more than once.
+func (c *Client) track(req *http.Request) (*http.Request, func(), error) {
+	c.life.mu.Lock()
+	if c.life.closed {
+		c.life.mu.Unlock()
+		return nil, nil, ErrClientShutdown
+	}
+	c.life.wg.Add(1)
+	c.life.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(c.life.ctx, cancel)
+	done := sync.OnceFunc(func() {
+		stop()
+		cancel()
+		c.life.wg.Done()
+	})
+	return req.WithContext(ctx), done, nil
+}
+
+// trackedBody marks its request as finished when closed.
+type trackedBody struct {
+	io.ReadCloser
+	done func()
+}
+
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.done()
+	return err
+}
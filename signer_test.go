@@ -0,0 +1,92 @@
+package storagesdk
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestHMACSignerKnownAnswer pins the string to sign and the signature. A
+// change here breaks every deployment that verifies signatures.
+func TestHMACSignerKnownAnswer(t *testing.T) {
+	tests := []struct {
+		name, method, url, bodySHA256, want string
+	}{
+		{
+			name:       "with body and escaped URI",
+			method:     http.MethodPut,
+			url:        "https://storage.example/api/v1/files/f%201?x=a%26b",
+			bodySHA256: "015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862", // {"a":1}
+			want:       "17b09cbfd539e0d4818a25a6d57fb75fd93730bf35774aeece38637e373e274a",
+		},
+		{
+			name:       "without body",
+			method:     http.MethodGet,
+			url:        "https://storage.example/api/v1/files",
+			bodySHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			want:       "4f87966652d8138112f015d2a0147e3c33760233fef8c4e39848dd22008477eb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewHMACSigner("key-1", []byte("top-secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := signer.(*hmacSigner)
+			s.now = func() time.Time { return time.Unix(1700000000, 0) }
+			s.rand = bytes.NewReader([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Sign(req, tt.bodySHA256); err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			want := map[string]string{
+				HeaderSignature:          tt.want,
+				HeaderSignatureKeyID:     "key-1",
+				HeaderSignatureTimestamp: "1700000000",
+				HeaderSignatureNonce:     "000102030405060708090a0b0c0d0e0f",
+				HeaderContentSHA256:      tt.bodySHA256,
+			}
+			for header, value := range want {
+				if got := req.Header.Get(header); got != value {
+					t.Errorf("%s = %q, want %q", header, got, value)
+				}
+			}
+		})
+	}
+}
+
+// TestSignRequestHashesBody checks the body hash passed to the signer for
+// buffered and streamed bodies.
+func TestSignRequestHashesBody(t *testing.T) {
+	const want = "015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862" // {"a":1}
+	var got string
+	c := newTestClient(t, Config{BaseURL: "https://storage.example", Signer: signerFunc(func(req *http.Request, bodySHA256 string) error {
+		got = bodySHA256
+		return nil
+	})})
+	for _, streamed := range []bool{false, true} {
+		req, err := http.NewRequest(http.MethodPut, "https://storage.example/api/v1/files/f", bytes.NewReader([]byte(`{"a":1}`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if streamed {
+			req.GetBody = nil
+		}
+		if err := c.signRequest(req); err != nil {
+			t.Fatalf("signRequest: %v", err)
+		}
+		if got != want {
+			t.Errorf("streamed=%t: body SHA-256 = %s, want %s", streamed, got, want)
+		}
+	}
+}
+
+type signerFunc func(req *http.Request, bodySHA256 string) error
+
+func (f signerFunc) Sign(req *http.Request, bodySHA256 string) error { return f(req, bodySHA256) }
@@ -0,0 +1,79 @@
+package storagesdk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// spoolMemoryLimit is the size above which request bodies are spooled to a
+// temporary file instead of being held in memory.
+const spoolMemoryLimit = 32 << 20
+
+// checkTempDir verifies that dir exists, is a directory and is writable.
+func checkTempDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	f, err := os.CreateTemp(dir, ".storagesdk-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// spool buffers written data in memory up to spoolMemoryLimit and moves it to
+// a temporary file in dir (the OS default when empty) beyond that.
+type spool struct {
+	dir  string
+	buf  bytes.Buffer
+	file *os.File
+	size int64
+}
+
+func (s *spool) Write(p []byte) (int, error) {
+	if s.file == nil && s.buf.Len()+len(p) > spoolMemoryLimit {
+		f, err := os.CreateTemp(s.dir, ".storagesdk-spool-*")
+		if err != nil {
+			return 0, fmt.Errorf("spool to disk: %w", err)
+		}
+		s.file = f
+		if _, err := s.buf.WriteTo(f); err != nil {
+			return 0, fmt.Errorf("spool to disk: %w", err)
+		}
+	}
+	var n int
+	var err error
+	if s.file != nil {
+		n, err = s.file.Write(p)
+	} else {
+		n, err = s.buf.Write(p)
+	}
+	s.size += int64(n)
+	return n, err
+}
+
+// reader returns a fresh reader over everything written so far.
+func (s *spool) reader() io.Reader {
+	if s.file != nil {
+		return io.NewSectionReader(s.file, 0, s.size)
+	}
+	return bytes.NewReader(s.buf.Bytes())
+}
+
+// Close removes the temporary file, if any. It is safe to call more than once.
+func (s *spool) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	f := s.file
+	s.file = nil
+	f.Close()
+	return os.Remove(f.Name())
+}
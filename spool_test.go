@@ -0,0 +1,122 @@
+package storagesdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeTestFile writes size bytes derived from seed to dir/name and returns
+// the path and SHA-256 of the content.
+func writeTestFile(t *testing.T, dir, name string, size int, seed byte) (string, [sha256.Size]byte) {
+	t.Helper()
+	data := bytes.Repeat([]byte{seed, seed + 1, seed + 2}, size/3+1)[:size]
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path, sha256.Sum256(data)
+}
+
+func TestConcurrentSpooledUploads(t *testing.T) {
+	const uploads = 4
+	size := spoolMemoryLimit + 1<<20
+	srcDir, spoolDir := t.TempDir(), t.TempDir()
+	want := make(map[string][sha256.Size]byte, uploads)
+	paths := make([]string, uploads)
+	for i := range paths {
+		name := fmt.Sprintf("file-%d.bin", i)
+		paths[i], want[name] = writeTestFile(t, srcDir, name, size, byte(i*7))
+	}
+
+	var mu sync.Mutex
+	got := make(map[string][sha256.Size]byte, uploads)
+	var spooled atomic.Bool
+	failing := func(name string) bool { return name == "file-0.bin" }
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if entries, _ := os.ReadDir(spoolDir); len(entries) > 0 {
+			spooled.Store(true)
+		}
+		mr, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var name string
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if part.FileName() == "" {
+				continue
+			}
+			name = part.FileName()
+			h := sha256.New()
+			if _, err := io.Copy(h, part); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			got[name] = [sha256.Size]byte(h.Sum(nil))
+			mu.Unlock()
+		}
+		if failing(name) {
+			http.Error(w, `{"success":false,"message":"disk full"}`, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"success":true,"status":201,"data":{"uploadedFiles":[{"id":%q,"originalName":%q}]}}`, name, name)
+	}))
+	defer srv.Close()
+	c := newTestClient(t, Config{BaseURL: srv.URL, TempDir: spoolDir, Timeout: time.Minute})
+
+	errs := make([]error, uploads)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c.UploadFile([]string{path}, "")
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		name := filepath.Base(paths[i])
+		if failing(name) {
+			if err == nil {
+				t.Errorf("%s: upload succeeded, want a server error", name)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if got[name] != want[name] {
+			t.Errorf("%s: body arrived corrupted", name)
+		}
+	}
+	if !spooled.Load() {
+		t.Error("no upload body was spooled to Config.TempDir")
+	}
+	entries, err := os.ReadDir(spoolDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		t.Errorf("left %s in Config.TempDir", e.Name())
+	}
+}
@@ -0,0 +1,71 @@
+package storagesdk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// minThroughputSample is the smallest upload used to measure throughput; smaller uploads
+// are dominated by latency rather than bandwidth.
+const minThroughputSample = 64 * 1024
+
+// throughputSmoothing weights the newest sample in the moving average.
+const throughputSmoothing = 0.3
+
+// ErrNoThroughputSample is returned by EstimateUploadTime before any upload large enough to
+// measure throughput has completed on the client.
+var ErrNoThroughputSample = errors.New("no upload throughput measured yet")
+
+// throughputStats tracks an exponentially weighted moving average of upload throughput.
+type throughputStats struct {
+	mu          sync.Mutex
+	bytesPerSec float64
+}
+
+func (s *throughputStats) record(bytes int64, elapsed time.Duration) {
+	if bytes < minThroughputSample || elapsed <= 0 {
+		return
+	}
+	rate := float64(bytes) / elapsed.Seconds()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.bytesPerSec == 0 {
+		s.bytesPerSec = rate
+		return
+	}
+	s.bytesPerSec = throughputSmoothing*rate + (1-throughputSmoothing)*s.bytesPerSec
+}
+
+func (s *throughputStats) rate() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bytesPerSec
+}
+
+// EstimateUploadTime estimates how long uploading filePaths would take, based on the
+// throughput measured from this client's previous uploads. It returns ErrNoThroughputSample
+// until an upload of at least 64 KiB has completed.
+func (c *Client) EstimateUploadTime(filePaths []string) (time.Duration, error) {
+	if len(filePaths) == 0 {
+		return 0, fmt.Errorf("at least one file path is required")
+	}
+	if err := validateFilePaths(filePaths); err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, p := range filePaths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return 0, err
+		}
+		total += info.Size()
+	}
+	rate := c.uploadStats.rate()
+	if rate == 0 {
+		return 0, ErrNoThroughputSample
+	}
+	return time.Duration(float64(total) / rate * float64(time.Second)), nil
+}
@@ -0,0 +1,35 @@
+package storagesdk
+
+import "fmt"
+
+// FileStatus is a file status as accepted by UpdateFile and reported in
+// FileItem.Status.
+type FileStatus string
+
+// File statuses understood by the storage service.
+const (
+	StatusActive   FileStatus = "active"
+	StatusInactive FileStatus = "inactive"
+	StatusArchived FileStatus = "archived"
+	StatusDeleted  FileStatus = "deleted"
+)
+
+// Valid reports whether s is one of the known statuses.
+func (s FileStatus) Valid() bool {
+	switch s {
+	case StatusActive, StatusInactive, StatusArchived, StatusDeleted:
+		return true
+	}
+	return false
+}
+
+// SetStatus sets the status to change with UpdateFile, rejecting unknown
+// statuses before anything is sent.
+func (r *UpdateFileRequest) SetStatus(status FileStatus) error {
+	if !status.Valid() {
+		return fmt.Errorf("unknown file status %q", status)
+	}
+	value := string(status)
+	r.Status = &value
+	return nil
+}
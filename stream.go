@@ -0,0 +1,53 @@
+package storagesdk
+
+import (
+	"fmt"
+	"io"
+)
+
+// UploadStreamThenTag uploads the content of r as a file called name, then
+// calls metaFn with the stored file and applies the metadata it returns via
+// UpdateFile. This lets metadata be derived from the content after the upload
+// has completed. Like UploadReader, r is streamed into the request with
+// chunked transfer encoding, without buffering it in memory or on disk, and
+// the upload is not retried. A nil or empty map from metaFn leaves the
+// metadata untouched. Metadata set by the SDK at upload time (such as the
+// encryption marker) is preserved; keys returned by metaFn take precedence.
+func (c *Client) UploadStreamThenTag(name string, r io.Reader, metaFn func(FileItem) map[string]interface{}, opts ...CallOption) (*GetFileResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("file name is required")
+	}
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	files := []formFile{{field: defaultUploadField, name: name, reader: r}}
+	uploaded, err := c.upload(files, UploadOptions{}, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := firstUploaded(name, uploaded)
+	if err != nil {
+		return nil, err
+	}
+	item := *stored
+
+	var extra map[string]interface{}
+	if metaFn != nil {
+		extra = metaFn(item)
+	}
+	if len(extra) == 0 {
+		return &GetFileResponse{Success: uploaded.Success, Message: uploaded.Message, Status: uploaded.Status, Data: item}, nil
+	}
+	metadata := make(map[string]interface{}, len(item.Metadata)+len(extra))
+	for k, v := range item.Metadata {
+		metadata[k] = v
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	result, err := c.UpdateFile(item.ID, UpdateFileRequest{Metadata: &metadata}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("file %s uploaded but tagging failed: %w", item.ID, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package storagesdk
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Query parameters for sorting list results.
+const (
+	sortByParam    = "sort_by"
+	sortOrderParam = "sort_order"
+)
+
+// ModifiedSinceQuery builds a list query for files updated at or after since, sorted by
+// updatedAt ascending with the file ID as tiebreaker, so files sharing a timestamp keep a
+// stable order across pages. It is combined with extraQuery (additional filters); sort_by and
+// sort_order in extraQuery are dropped, as they would break that order. Use it with ListFiles
+// or NewFileIterator to walk all changes since a watermark.
+//
+// since is converted to UTC and sent in RFC 3339 format with nanoseconds, so the watermark is
+// unambiguous regardless of the caller's or server's local time zone. The filter is inclusive:
+// files updated exactly at the watermark are returned again, so advance the watermark to the
+// last seen UpdatedAt and skip IDs already processed at that instant rather than adding an
+// offset, which could miss files sharing a timestamp.
+func ModifiedSinceQuery(since time.Time, extraQuery string) string {
+	q := url.Values{
+		"updated_at_gte": {since.UTC().Format(time.RFC3339Nano)},
+		sortByParam:      {"updated_at,id"},
+		sortOrderParam:   {"asc"},
+	}.Encode()
+	for _, pair := range strings.Split(extraQuery, "&") {
+		key, _, _ := strings.Cut(pair, "=")
+		if key, err := url.QueryUnescape(key); err == nil && (key == sortByParam || key == sortOrderParam) {
+			continue
+		}
+		if pair != "" {
+			q += "&" + pair
+		}
+	}
+	return q
+}
+
+// ListFilesModifiedSince lists files updated at or after since in stable updatedAt, ID order (see
+// ModifiedSinceQuery for watermark and time zone handling). extraQuery may add filters and
+// page/per_page parameters.
+func (c *Client) ListFilesModifiedSince(since time.Time, extraQuery string, opts ...CallOption) (*ListFilesResponse, error) {
+	return c.ListFiles(ModifiedSinceQuery(since, extraQuery), opts...)
+}
@@ -0,0 +1,105 @@
+package storagesdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// tagsMetadataKey is the metadata key holding a file's tags.
+const tagsMetadataKey = "tags"
+
+// TagResult is the per-file outcome of BulkAddTags.
+type TagResult struct {
+	FileID string   // File the tags were applied to
+	Tags   []string // Resulting tags (nil on error)
+	Err    error    // Non-nil if tagging this file failed
+}
+
+// Tags returns the file's tags from metadata["tags"].
+func (f FileItem) Tags() []string {
+	return metadataTags(f.Metadata)
+}
+
+// BulkTagOptions configures BulkAddTags.
+type BulkTagOptions struct {
+	// Concurrency bounds the files tagged at once (default 4).
+	Concurrency int
+}
+
+// AddTags adds tags to a file's metadata["tags"] list, skipping tags already present. Other
+// metadata keys are preserved (read-modify-write with conflict retry).
+func (c *Client) AddTags(fileID string, tags []string, opts ...CallOption) (*GetFileResponse, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required")
+	}
+	return c.updateMetadata(fileID, func(metadata map[string]interface{}) bool {
+		current := metadataTags(metadata)
+		seen := make(map[string]bool, len(current))
+		for _, t := range current {
+			seen[t] = true
+		}
+		changed := false
+		for _, t := range tags {
+			if t != "" && !seen[t] {
+				seen[t] = true
+				current = append(current, t)
+				changed = true
+			}
+		}
+		if changed {
+			metadata[tagsMetadataKey] = current
+		}
+		return changed
+	}, opts...)
+}
+
+// BulkAddTags applies tags to many files with at most opts.Concurrency files in flight and
+// returns one result per file ID (in input order). Failures on individual files do not abort
+// the others. ctx cancels all in-flight requests; files not yet started then fail with its
+// error. callOpts apply to every request.
+func (c *Client) BulkAddTags(ctx context.Context, fileIDs, tags []string, opts BulkTagOptions, callOpts ...CallOption) ([]TagResult, error) {
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	callOpts = withOpts(callOpts, WithContext(ctx))
+	results := make([]TagResult, len(fileIDs))
+	forEachConcurrent(len(fileIDs), opts.Concurrency, func(i int) {
+		results[i].FileID = fileIDs[i]
+		if err := ctx.Err(); err != nil {
+			results[i].Err = fmt.Errorf("file %s: %w", fileIDs[i], err)
+			return
+		}
+		resp, err := c.AddTags(fileIDs[i], tags, callOpts...)
+		if err != nil {
+			results[i].Err = err
+			return
+		}
+		results[i].Tags = resp.Data.Tags()
+	})
+	return results, nil
+}
+
+func metadataTags(metadata map[string]interface{}) []string {
+	switch v := metadata[tagsMetadataKey].(type) {
+	case []string:
+		return append([]string(nil), v...)
+	case []interface{}:
+		tags := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+		return tags
+	}
+	return nil
+}
@@ -0,0 +1,574 @@
+package storagesdk
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UploadOptions configures UploadFileWithOptions.
+type UploadOptions struct {
+	// Metadata is an optional JSON object string applied to all files.
+	Metadata string
+
+	// Folder is an optional destination folder, sent as the "folder" form
+	// field. It is placed under Config.PathPrefix when one is configured.
+	Folder string
+
+	// ComputeHashes computes each file's SHA-256 before upload and sends the
+	// list (in file order) as the "hashes" form field so the server can reject
+	// corrupted transfers. Returned files whose Hash differs from the computed
+	// one produce a *HashMismatchError.
+	ComputeHashes bool
+
+	// IfNotExists sends If-None-Match: * so the server rejects the upload
+	// instead of overwriting a matching existing file; the error then wraps
+	// ErrAlreadyExists.
+	IfNotExists bool
+
+	// ExpiresAt or TTL (mutually exclusive) ask the service to delete the
+	// files automatically at that time. The expiry is sent as the "expiresAt"
+	// form field and recorded in metadata["expiresAt"] (see FileItem.ExpiresAt).
+	ExpiresAt time.Time
+	TTL       time.Duration
+
+	// SkipOversized checks each file against GetFileLimits (or, with Folder
+	// set, GetFileLimitsFor that folder) before building the request and
+	// leaves out files over their extension's limit, uploading the rest;
+	// skipped files are reported in UploadFileResponse.Skipped. If every file
+	// is skipped, no request is sent.
+	SkipOversized bool
+	// RenameDuplicates checks each file name against existing files (see
+	// ListFilesByName) and against the other files of the upload, and renames
+	// duplicates before sending by appending " (2)", " (3)", ... to the name
+	// ("report (2).pdf"). Renamed files are reported in UploadFileResponse.Renamed.
+	RenameDuplicates bool
+
+	// FieldsByMIME routes files to multipart fields by MIME type: keys are MIME
+	// prefixes ("image/", "application/pdf"), values are field names. The type
+	// is detected from the file extension, falling back to content sniffing; the
+	// longest matching prefix wins and unmatched files go to "files".
+	FieldsByMIME map[string]string
+	// CreateParents asks the service to create missing folders of the
+	// destination (Folder under Config.PathPrefix) by sending the
+	// "createParents" form field. If the upload is still rejected for the
+	// folder, the SDK creates it with CreateFolder and retries once; an error
+	// explains when neither is possible.
+	CreateParents bool
+
+	// FormValues are extra form fields sent with the upload; every value of a
+	// key is written as a separate field, for APIs expecting repeated fields
+	// such as "tags[]". Fields the SDK sets itself (metadata, folder,
+	// expiresAt, createParents, hashes) cannot be given here. Chunked uploads
+	// send them in the session's JSON body, as a string for a single value and
+	// an array otherwise.
+	FormValues map[string][]string
+
+	// FileNames uploads files under a name other than their on-disk one, keyed
+	// by the local path as passed in filePaths (e.g. a temp file mapped to
+	// "report.pdf"). Paths without an entry keep their base name. RenameDuplicates
+	// and hash checks apply to the chosen names.
+	FileNames map[string]string
+
+	// Progress, when set, is called as the request body is sent, with the
+	// bytes sent so far and the total body size, multipart overhead
+	// included (see WithProgress). A retried upload starts again from 0.
+	Progress ProgressFunc
+}
+
+// SkippedFile describes a file left out of an upload client-side.
+type SkippedFile struct {
+	Path    string // Local path
+	Size    int64  // File size in bytes
+	MaxSize int64  // Applicable size limit in bytes
+	Reason  string // Human-readable reason
+}
+
+// expiresAtMetadataKey is the metadata key recording a file's scheduled expiry.
+const expiresAtMetadataKey = "expiresAt"
+
+// expiry resolves ExpiresAt/TTL to an absolute time (zero when neither is set).
+func (o UploadOptions) expiry() (time.Time, error) {
+	switch {
+	case !o.ExpiresAt.IsZero() && o.TTL != 0:
+		return time.Time{}, fmt.Errorf("ExpiresAt and TTL are mutually exclusive")
+	case o.TTL < 0:
+		return time.Time{}, fmt.Errorf("TTL must be positive")
+	case o.TTL > 0:
+		return time.Now().Add(o.TTL), nil
+	}
+	return o.ExpiresAt, nil
+}
+
+// ExpiresAt returns the file's scheduled expiry recorded in metadata["expiresAt"], if any.
+func (f FileItem) ExpiresAt() (time.Time, bool) {
+	s, ok := f.Metadata[expiresAtMetadataKey].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// NamedReader is content to upload from memory or a stream, with the file
+// name it is stored under.
+type NamedReader struct {
+	Name   string
+	Reader io.Reader
+}
+
+// UploadReader uploads the content of readers in one multipart request, sent
+// in the form field field (defaultUploadField, "files", when empty). Each
+// reader is streamed into the request with chunked transfer encoding as it is
+// sent, so nothing is buffered in memory or written to Config.TempDir first.
+// metadataJSON is an optional JSON object string applied to all files.
+// Uploads of readers cannot be replayed and are never retried.
+func (c *Client) UploadReader(field string, readers []NamedReader, metadataJSON string, opts ...CallOption) (*UploadFileResponse, error) {
+	if len(readers) == 0 {
+		return nil, fmt.Errorf("at least one reader is required")
+	}
+	if field == "" {
+		field = defaultUploadField
+	}
+	files := make([]formFile, len(readers))
+	for i, r := range readers {
+		if r.Name == "" {
+			return nil, fmt.Errorf("file name is required for reader %d", i)
+		}
+		if r.Reader == nil {
+			return nil, fmt.Errorf("reader is required for %s", r.Name)
+		}
+		files[i] = formFile{field: field, name: r.Name, reader: r.Reader}
+	}
+	return c.upload(files, UploadOptions{Metadata: metadataJSON}, nil, opts)
+}
+
+// FileWithMetadata is a local file to upload together with its own metadata.
+type FileWithMetadata struct {
+	Path     string
+	Metadata map[string]interface{} // optional; nil sends no per-file metadata
+}
+
+// UploadFilesWithMetadata uploads local files in one request, each with its
+// own metadata, sent as the form fields metadata[0], metadata[1], ... in the
+// order of files. Metadata set by the SDK (such as the encryption marker) is
+// sent in the shared metadata field as with UploadFile.
+func (c *Client) UploadFilesWithMetadata(files []FileWithMetadata, opts ...CallOption) (*UploadFileResponse, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("at least one file path is required")
+	}
+	filePaths := make([]string, len(files))
+	formValues := make(url.Values)
+	for i, f := range files {
+		filePaths[i] = f.Path
+		if f.Metadata == nil {
+			continue
+		}
+		raw, err := json.Marshal(f.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload files: metadata for %s: %w", f.Path, err)
+		}
+		formValues.Set("metadata["+strconv.Itoa(i)+"]", string(raw))
+	}
+	if err := validateFilePaths(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to upload files: %w", err)
+	}
+	if err := c.checkUploadPolicy(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to upload files: %w", err)
+	}
+	return c.upload(pathFormFiles(defaultUploadField, filePaths), UploadOptions{}, formValues, opts)
+}
+
+// UploadFileWithOptions uploads one or more files from local paths with additional upload options.
+func (c *Client) UploadFileWithOptions(filePaths []string, opts UploadOptions, callOpts ...CallOption) (*UploadFileResponse, error) {
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("at least one file path is required")
+	}
+	if err := validateFilePaths(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to upload files: %w", err)
+	}
+	if err := c.checkUploadPolicy(filePaths); err != nil {
+		return nil, fmt.Errorf("failed to upload files: %w", err)
+	}
+	if err := checkFileNames(filePaths, opts.FileNames); err != nil {
+		return nil, fmt.Errorf("failed to upload files: %w", err)
+	}
+	if opts.ComputeHashes && c.encryption != nil {
+		return nil, fmt.Errorf("failed to upload files: ComputeHashes cannot be combined with client-side encryption")
+	}
+	var skipped []SkippedFile
+	if opts.SkipOversized {
+		kept, s, err := c.filterOversized(filePaths, opts.Folder, callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload files: %w", err)
+		}
+		if len(kept) == 0 {
+			return &UploadFileResponse{Skipped: s}, nil
+		}
+		filePaths, skipped = kept, s
+	}
+	files := pathFormFiles(defaultUploadField, filePaths)
+	for i := range files {
+		if name, ok := opts.FileNames[files[i].path]; ok {
+			files[i].name = name
+		}
+	}
+	if len(opts.FieldsByMIME) > 0 {
+		if err := routeByMIME(files, opts.FieldsByMIME); err != nil {
+			return nil, fmt.Errorf("failed to upload files: detect MIME type: %w", err)
+		}
+	}
+	var renamed map[string]string
+	if opts.RenameDuplicates {
+		r, err := c.renameDuplicates(files, callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload files: %w", err)
+		}
+		renamed = r
+	}
+
+	formValues := make(url.Values)
+	var hashes map[string][]string
+	if opts.ComputeHashes {
+		list := make([]string, 0, len(files))
+		hashes = make(map[string][]string, len(files))
+		for _, f := range files {
+			sum, err := hashFile(f.path, sha256.New)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload files: hash %s: %w", f.path, err)
+			}
+			list = append(list, sum)
+			hashes[f.name] = append(hashes[f.name], sum)
+		}
+		raw, err := json.Marshal(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload files: marshal hashes: %w", err)
+		}
+		formValues.Set("hashes", string(raw))
+	}
+
+	result, err := c.upload(files, opts, formValues, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if hashes != nil {
+		if err := checkUploadedHashes(result.Data.UploadedFiles, hashes); err != nil {
+			return nil, err
+		}
+	}
+	result.Skipped = skipped
+	result.Renamed = renamed
+	return result, nil
+}
+
+// checkFileNames validates UploadOptions.FileNames: every key must be one of
+// filePaths and every name a plain file name.
+func checkFileNames(filePaths []string, names map[string]string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	known := make(map[string]bool, len(filePaths))
+	for _, p := range filePaths {
+		known[p] = true
+	}
+	for p, name := range names {
+		if !known[p] {
+			return fmt.Errorf("file name given for %s, which is not uploaded", p)
+		}
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+			return fmt.Errorf("invalid file name %q for %s", name, p)
+		}
+	}
+	return nil
+}
+
+// sdkFormFields are the upload form fields the SDK sets from UploadOptions.
+var sdkFormFields = map[string]bool{
+	"metadata": true, "folder": true, "expiresAt": true, "createParents": true, "hashes": true, "expand": true,
+}
+
+// prepareUpload applies the options shared by every upload variant to the
+// form values and call options: metadata, expiry, encryption, folder and
+// IfNotExists, plus the caller's extra FormValues. formValues may be nil.
+func (c *Client) prepareUpload(opts UploadOptions, formValues url.Values, callOpts []CallOption) (url.Values, []CallOption, error) {
+	if formValues == nil {
+		formValues = make(url.Values)
+	}
+	metadata := opts.Metadata
+	if expiresAt, err := opts.expiry(); err != nil {
+		return nil, nil, fmt.Errorf("failed to upload files: %w", err)
+	} else if !expiresAt.IsZero() {
+		value := expiresAt.UTC().Format(time.RFC3339)
+		formValues.Set("expiresAt", value)
+		merged, err := mergeMetadataJSON(metadata, map[string]interface{}{expiresAtMetadataKey: value})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upload files: %w", err)
+		}
+		metadata = merged
+	}
+	if c.encryption != nil {
+		merged, err := mergeMetadataJSON(metadata, map[string]interface{}{encryptedMetadataKey: true})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upload files: %w", err)
+		}
+		metadata = merged
+		callOpts = withOpts(callOpts, withEncryption())
+	}
+	if metadata != "" {
+		formValues.Set("metadata", metadata)
+	}
+	if folder := c.uploadFolder(opts.Folder); folder != "" {
+		formValues.Set("folder", folder)
+		if opts.CreateParents {
+			formValues.Set("createParents", "true")
+		}
+	}
+	for k, values := range opts.FormValues {
+		if _, ok := formValues[k]; ok || sdkFormFields[k] {
+			return nil, nil, fmt.Errorf("failed to upload files: form field %q is set by the SDK", k)
+		}
+		formValues[k] = append([]string(nil), values...)
+	}
+	if opts.IfNotExists {
+		callOpts = withOpts(callOpts, WithHeader("If-None-Match", "*"))
+	}
+	if opts.Progress != nil {
+		callOpts = withOpts(callOpts, WithProgress(opts.Progress))
+	}
+	return formValues, callOpts, nil
+}
+
+// upload posts files to the upload endpoint, applying the options shared by
+// every upload variant (see prepareUpload). formValues may carry extra fields
+// and is modified in place.
+func (c *Client) upload(files []formFile, opts UploadOptions, formValues url.Values, callOpts []CallOption) (*UploadFileResponse, error) {
+	formValues, callOpts, err := c.prepareUpload(opts, formValues, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	var result UploadFileResponse
+	callOpts = withOpts(callOpts, withStatusCode(&result.httpStatus))
+	send := func() error {
+		return c.doMultipart(apiPathPrefix+"/files/", files, formValues, []int{http.StatusCreated, http.StatusPartialContent}, &result, "failed to upload files", callOpts...)
+	}
+	err = c.retryUpload(files, callOpts, send)
+	if err != nil && opts.CreateParents && replayable(files) {
+		var retry bool
+		if retry, err = c.createParentsAndRetry(formValues.Get("folder"), err, callOpts); retry {
+			err = c.retryUpload(files, callOpts, send)
+		}
+	}
+	if err != nil {
+		if opts.IfNotExists {
+			return nil, asAlreadyExists(err)
+		}
+		return nil, err
+	}
+	result.fillFailedUploads(files)
+	return &result, nil
+}
+
+// filterOversized splits filePaths into files within their size limit and skipped ones.
+// Uploads into a folder are checked against that folder's limits.
+func (c *Client) filterOversized(filePaths []string, folder string, callOpts []CallOption) ([]string, []SkippedFile, error) {
+	var limits *GetFileLimitsResponse
+	var err error
+	if folder != "" {
+		limits, err = c.GetFileLimitsFor(LimitsContext{Folder: folder}, metadataOpts(callOpts)...)
+	} else {
+		limits, err = c.GetFileLimits(metadataOpts(callOpts)...)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("get file limits: %w", err)
+	}
+	var kept []string
+	var skipped []SkippedFile
+	for _, p := range filePaths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		if limit := limits.maxSizeFor(p); limit > 0 && info.Size() > limit {
+			skipped = append(skipped, SkippedFile{
+				Path:    p,
+				Size:    info.Size(),
+				MaxSize: limit,
+				Reason:  fmt.Sprintf("file size %d exceeds limit %d", info.Size(), limit),
+			})
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept, skipped, nil
+}
+
+// maxSizeFor returns the size limit for a file name: its extension's limit if listed,
+// otherwise the default (0 means unlimited).
+func (r *GetFileLimitsResponse) maxSizeFor(name string) int64 {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	if ext != "" {
+		for k, v := range r.Data.Extensions {
+			if strings.ToLower(strings.TrimPrefix(k, ".")) == ext {
+				return v
+			}
+		}
+	}
+	return r.Data.DefaultMaxSize
+}
+
+// UploadArchive uploads a tar or zip archive. With expand set, the archive is sent to the
+// service's archive endpoint, which expands it into individual files (returned in
+// Data.UploadedFiles) in a single round trip; metadataJSON is applied to every extracted file.
+// Without expand the archive is stored as a single file. Expansion returns an error wrapping
+// ErrNotSupported if the service has no archive endpoint.
+func (c *Client) UploadArchive(archivePath string, expand bool, metadataJSON string, opts ...CallOption) (*UploadFileResponse, error) {
+	if archivePath == "" {
+		return nil, fmt.Errorf("archive path is required")
+	}
+	if !expand {
+		return c.UploadFile([]string{archivePath}, metadataJSON, opts...)
+	}
+	if c.encryption != nil {
+		return nil, fmt.Errorf("failed to upload archive: server-side expansion is not possible with client-side encryption")
+	}
+	if err := c.checkUploadPolicy([]string{archivePath}); err != nil {
+		return nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+	formValues := url.Values{"expand": {"true"}}
+	if metadataJSON != "" {
+		formValues.Set("metadata", metadataJSON)
+	}
+	if folder := c.uploadFolder(""); folder != "" {
+		formValues.Set("folder", folder)
+	}
+	var result UploadFileResponse
+	files := pathFormFiles("archive", []string{archivePath})
+	err := c.retryUpload(files, opts, func() error {
+		return c.doMultipart(apiPathPrefix+"/files/archive", files, formValues, []int{http.StatusCreated, http.StatusPartialContent}, &result, "failed to upload archive", opts...)
+	})
+	if err != nil {
+		return nil, asNotSupported(err)
+	}
+	return &result, nil
+}
+
+// validateFilePaths checks that every path is non-empty, exists and is not a directory,
+// reporting all problems at once so nothing is opened for an upload that cannot succeed.
+func validateFilePaths(paths []string) error {
+	var errs []error
+	for i, p := range paths {
+		if strings.TrimSpace(p) == "" {
+			errs = append(errs, fmt.Errorf("file path #%d is empty", i+1))
+			continue
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if info.IsDir() {
+			errs = append(errs, fmt.Errorf("%s is a directory", p))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkUploadPolicy runs Config.UploadPolicy on every path, reporting all
+// rejected files at once.
+func (c *Client) checkUploadPolicy(paths []string) error {
+	if c.policy == nil {
+		return nil
+	}
+	var errs []error
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := c.policy(p, info); err != nil {
+			errs = append(errs, fmt.Errorf("%s rejected by upload policy: %w", p, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkUploadedHashes compares server-reported hashes with those computed
+// before upload, keyed by original name.
+func checkUploadedHashes(files []FileItem, hashes map[string][]string) error {
+	for _, f := range files {
+		expected := hashes[f.OriginalName]
+		if len(expected) == 0 || !hashesComparable(expected[0], f.Hash) {
+			continue
+		}
+		matched := false
+		for _, sum := range expected {
+			if hashEqual(sum, f.Hash) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return &HashMismatchError{FileID: f.ID, Name: f.OriginalName, Expected: expected[0], Actual: f.Hash, Algorithm: "sha256"}
+		}
+	}
+	return nil
+}
+
+// pathPrefixFilter is the list filter used to scope results to Config.PathPrefix.
+const pathPrefixFilter = "file_path_like"
+
+// likeEscaper escapes the LIKE metacharacters of a literal pattern prefix.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// queryHasKey reports whether the query string has a parameter named key.
+func queryHasKey(queryString, key string) bool {
+	for _, pair := range strings.Split(queryString, "&") {
+		k, _, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(k); err == nil && k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadFolder joins the client's path prefix with a per-upload folder.
+func (c *Client) uploadFolder(folder string) string {
+	folder = strings.Trim(folder, "/")
+	if c.pathPrefix == "" {
+		return folder
+	}
+	if folder == "" {
+		return c.pathPrefix
+	}
+	return path.Join(c.pathPrefix, folder)
+}
+
+// scopeQuery restricts a list query to the client's path prefix unless the
+// caller already filters on it. The pattern is anchored at the start of the
+// file path, so a prefix does not match paths that merely contain it (such as
+// "other/billing-app/..."), and LIKE wildcards in the prefix are escaped, so
+// "my_app" does not match "myXapp/...".
+func (c *Client) scopeQuery(queryString string) string {
+	if c.pathPrefix == "" || queryHasKey(queryString, pathPrefixFilter) {
+		return queryString
+	}
+	scope := url.Values{pathPrefixFilter: {likeEscaper.Replace(c.pathPrefix) + "/%"}}.Encode()
+	if queryString == "" {
+		return scope
+	}
+	return queryString + "&" + scope
+}
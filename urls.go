@@ -0,0 +1,35 @@
+package storagesdk
+
+// FileContentURL returns the absolute URL serving a file's content inline
+// (GET /api/v1/files/:id/content), e.g. for <img> or <video> sources. The URL
+// carries no credentials.
+func (c *Client) FileContentURL(fileID string) string {
+	return c.baseURL + apiPathPrefix + "/files/" + pathSeg(fileID) + "/content"
+}
+
+// FileDownloadURL returns the absolute URL downloading a file as an attachment
+// (GET /api/v1/files/:id?download=true). The URL carries no credentials.
+func (c *Client) FileDownloadURL(fileID string) string {
+	return c.baseURL + apiPathPrefix + "/files/" + pathSeg(fileID) + "?download=true"
+}
+
+// ContentURLs returns FileContentURL for every item of a listing, in order.
+func (c *Client) ContentURLs(list *ListFilesResponse) []string {
+	return c.fileURLs(list, c.FileContentURL)
+}
+
+// DownloadURLs returns FileDownloadURL for every item of a listing, in order.
+func (c *Client) DownloadURLs(list *ListFilesResponse) []string {
+	return c.fileURLs(list, c.FileDownloadURL)
+}
+
+func (c *Client) fileURLs(list *ListFilesResponse, build func(string) string) []string {
+	if list == nil {
+		return nil
+	}
+	urls := make([]string, len(list.Data))
+	for i, f := range list.Data {
+		urls[i] = build(f.ID)
+	}
+	return urls
+}
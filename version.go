@@ -0,0 +1,74 @@
+package storagesdk
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Version is the version of this SDK, sent in the default User-Agent.
+const Version = "0.1.0"
+
+// DefaultUserAgent is the User-Agent sent when Config.UserAgent is empty.
+const DefaultUserAgent = "storage-service-sdk-go/" + Version
+
+// SupportedAPIVersion is the major storage service API version this SDK targets
+// (it matches the /api/v1 path prefix).
+const SupportedAPIVersion = "1"
+
+// APIVersionMismatchError is returned when the server reports an API version
+// whose major version differs from SupportedAPIVersion.
+type APIVersionMismatchError struct {
+	ServerVersion string // Version reported by the server
+	SDKVersion    string // Major version the SDK expects
+}
+
+// Error implements the error interface
+func (e *APIVersionMismatchError) Error() string {
+	return fmt.Sprintf("storage service API version %q is incompatible with SDK API version %s", e.ServerVersion, e.SDKVersion)
+}
+
+// GetAPIVersionResponse represents the response from the version endpoint
+type GetAPIVersionResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+	Data    struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
+
+// GetAPIVersion returns the API version reported by the storage service.
+func (c *Client) GetAPIVersion(opts ...CallOption) (string, error) {
+	var result GetAPIVersionResponse
+	err := c.do(http.MethodGet, apiPathPrefix+"/version", nil, []int{http.StatusOK}, &result, "failed to get API version", opts...)
+	if err != nil {
+		return "", err
+	}
+	if result.Data.Version == "" {
+		return "", fmt.Errorf("failed to get API version: empty version in response")
+	}
+	return result.Data.Version, nil
+}
+
+// CheckAPIVersion fetches the server's API version and returns an
+// *APIVersionMismatchError if its major version differs from SupportedAPIVersion.
+func (c *Client) CheckAPIVersion(opts ...CallOption) error {
+	version, err := c.GetAPIVersion(opts...)
+	if err != nil {
+		return err
+	}
+	if majorVersion(version) != SupportedAPIVersion {
+		return &APIVersionMismatchError{ServerVersion: version, SDKVersion: SupportedAPIVersion}
+	}
+	return nil
+}
+
+// majorVersion extracts the major component of versions like "1", "v1" or "1.4.2".
+func majorVersion(v string) string {
+	v = strings.TrimPrefix(strings.TrimSpace(strings.ToLower(v)), "v")
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		v = v[:i]
+	}
+	return v
+}
@@ -0,0 +1,79 @@
+package storagesdk
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FileVersion represents one entry of a file's version history
+type FileVersion struct {
+	ID        string `json:"id"`
+	FileID    string `json:"fileId"`
+	Version   int    `json:"version"`
+	FileSize  int64  `json:"fileSize"`
+	MimeType  string `json:"mimeType"`
+	Hash      string `json:"hash"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// GetFileVersionsResponse represents the response from listing a file's versions
+type GetFileVersionsResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Status  int           `json:"status"`
+	Data    []FileVersion `json:"data"`
+}
+
+// GetFileVersions returns the version history of a file. It returns an error wrapping
+// ErrNotSupported if the service does not track versions.
+func (c *Client) GetFileVersions(fileID string, opts ...CallOption) ([]FileVersion, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "/versions"
+	var result GetFileVersionsResponse
+	err := c.do(http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get file versions", opts...)
+	if err != nil {
+		return nil, asNotSupported(err)
+	}
+	return result.Data, nil
+}
+
+// DownloadVersion downloads a specific version of a file and returns the HTTP response.
+// Caller must close resp.Body.
+func (c *Client) DownloadVersion(fileID, versionID string, opts ...CallOption) (*http.Response, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if versionID == "" {
+		return nil, fmt.Errorf("version ID is required")
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "/versions/" + pathSeg(versionID) + "?download=true"
+	resp, err := c.doRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file version: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, asNotSupported(c.apiError(resp.StatusCode, body))
+	}
+	if err := c.decryptResponse(resp, fileID, nil, opts); err != nil {
+		return nil, fmt.Errorf("failed to download file version: %w", err)
+	}
+	return resp, nil
+}
+
+// PurgeFile permanently deletes a file and all of its historical versions, unlike
+// DeleteFile which may only remove the current version. It returns an error wrapping
+// ErrNotSupported if the service has no purge endpoint, so callers relying on it for
+// erasure requests know nothing was purged.
+func (c *Client) PurgeFile(fileID string, opts ...CallOption) error {
+	if fileID == "" {
+		return fmt.Errorf("file ID is required")
+	}
+	path := apiPathPrefix + "/files/" + pathSeg(fileID) + "/purge"
+	err := c.do(http.MethodDelete, path, nil, []int{http.StatusOK, http.StatusNoContent}, nil, "failed to purge file", opts...)
+	return asNotSupported(err)
+}
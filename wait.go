@@ -0,0 +1,64 @@
+package storagesdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultPollInterval = time.Second
+
+// WaitUntilStatus polls GetFile every pollInterval (default 1s) until the file reaches status
+// (e.g. "active" after an asynchronous conversion) and returns its metadata. It gives up after
+// timeout (no limit when 0) or when the call context (WithContext) is done, returning an error
+// wrapping context.DeadlineExceeded or the context's error. A file that becomes "deleted"
+// while waiting for another status fails immediately. With WithPollBackoff the interval
+// doubles after every poll, up to the given maximum.
+func (c *Client) WaitUntilStatus(fileID, status string, pollInterval, timeout time.Duration, opts ...CallOption) (*GetFileResponse, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+	if status == "" {
+		return nil, fmt.Errorf("status is required")
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	co := newCallOptions(opts)
+	ctx := co.context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	opts = withOpts(opts, WithContext(ctx))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	last := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for file %s to become %q (last status %q): %w", fileID, status, last, ctx.Err())
+		case <-timer.C:
+		}
+		file, err := c.GetFile(fileID, opts...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("waiting for file %s to become %q (last status %q): %w", fileID, status, last, ctx.Err())
+			}
+			return nil, err
+		}
+		last = file.Data.Status
+		if last == status {
+			return file, nil
+		}
+		if last == string(StatusDeleted) {
+			return nil, fmt.Errorf("file %s was deleted while waiting for status %q", fileID, status)
+		}
+		timer.Reset(pollInterval)
+		if co.pollMax > pollInterval {
+			pollInterval = min(pollInterval*2, co.pollMax)
+		}
+	}
+}
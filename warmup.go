@@ -0,0 +1,40 @@
+package storagesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Warmup primes the connection pool before a burst by issuing n concurrent lightweight HEAD
+// requests, so the burst does not pay connection (and TLS) setup latency. Any HTTP response
+// counts as success; only transport errors are returned. At most Config.MaxIdleConnsPerHost
+// connections are kept after warmup, so set it to at least n.
+func (c *Client) Warmup(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("connection count must be positive")
+	}
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := c.doRequest(http.MethodHead, apiPathPrefix+"/files/limits", nil, WithContext(ctx))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}(i)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to warm up connections: %w", err)
+	}
+	return nil
+}